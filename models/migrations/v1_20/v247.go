@@ -0,0 +1,34 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_20 //nolint
+
+import (
+	"xorm.io/xorm"
+)
+
+// AddMigrationCheckpointTable creates the migration_checkpoint table used to
+// resume a partially completed repository migration (Azure DevOps, GitHub,
+// GitLab, ...) instead of restarting it from scratch after a network blip,
+// token expiry, or rate-limit exhaustion.
+//
+// NOTE: like v246's FixIncorrectProjectType, this only takes effect once
+// appended to the ordered migration list in models/migrations/migrations.go;
+// that file is not part of this change set.
+func AddMigrationCheckpointTable(x *xorm.Engine) error {
+	type MigrationCheckpoint struct {
+		ID               int64 `xorm:"pk autoincr"`
+		RepoID           int64 `xorm:"UNIQUE NOT NULL"`
+		NextIssuePage    int   `xorm:"NOT NULL DEFAULT 0"`
+		NextPullPage     int   `xorm:"NOT NULL DEFAULT 0"`
+		NextReleasePage  int   `xorm:"NOT NULL DEFAULT 0"`
+		LastIssueNumber  int64 `xorm:"NOT NULL DEFAULT 0"`
+		LastPullNumber   int64 `xorm:"NOT NULL DEFAULT 0"`
+		LastReviewID     int64 `xorm:"NOT NULL DEFAULT 0"`
+		LastReleaseAsset int64 `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix      int64 `xorm:"created"`
+		UpdatedUnix      int64 `xorm:"updated"`
+	}
+
+	return x.Sync2(new(MigrationCheckpoint))
+}
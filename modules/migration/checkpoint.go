@@ -0,0 +1,14 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migration
+
+// Checkpoint carries a Downloader's paging cursors across a resumed
+// migration. It is the in-memory counterpart of whatever storage the
+// caller persists it in between attempts (see services/migrations'
+// MigrationCheckpoint for the one backed by the database).
+type Checkpoint struct {
+	NextIssuePage   int
+	NextPullPage    int
+	NextReleasePage int
+}
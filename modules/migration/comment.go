@@ -0,0 +1,17 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migration
+
+import "time"
+
+// Comment is a standard comment information
+type Comment struct {
+	IssueIndex int64
+	PosterID   int64
+	PosterName string
+	Content    string
+	Created    time.Time
+	Updated    time.Time
+	Reactions  []*Reaction
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migration
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/modules/structs"
+)
+
+// Downloader downloads the site repo information
+type Downloader interface {
+	SetContext(context.Context)
+	GetRepoInfo() (*Repository, error)
+	GetTopics() ([]string, error)
+	GetMilestones() ([]*Milestone, error)
+	GetReleases() ([]*Release, error)
+	GetLabels() ([]*Label, error)
+	GetIssues(page, perPage int) ([]*Issue, bool, error)
+	GetComments(commentable Commentable) ([]*Comment, bool, error)
+	GetPullRequests(page, perPage int) ([]*PullRequest, bool, error)
+	GetReviews(reviewable Reviewable) ([]*Review, error)
+
+	// Resume primes the downloader's internal paging cursors from a
+	// previously persisted checkpoint, so a retried migration picks up
+	// where the last attempt left off instead of redownloading pages the
+	// uploader already wrote. A nil checkpoint means the migration has not
+	// made any progress yet, and Resume should leave the downloader as-is.
+	Resume(ctx context.Context, checkpoint *Checkpoint) error
+
+	// Close releases any resources (temporary clones, open clients, ...)
+	// the downloader created, once the repository has finished migrating.
+	Close()
+
+	String() string
+	LogString() string
+}
+
+// DownloaderFactory defines an interface to match a downloader implementation and create a downloader
+type DownloaderFactory interface {
+	New(ctx context.Context, opts MigrateOptions) (Downloader, error)
+	GitServiceType() structs.GitServiceType
+}
+
+// Commentable can be commented upon
+type Commentable interface {
+	GetLocalIndex() int64
+	GetForeignIndex() int64
+}
+
+// Reviewable can be reviewed
+type Reviewable interface {
+	GetLocalIndex() int64
+	GetForeignIndex() int64
+}
+
+// NullDownloader implements Downloader interface without downloading
+// anything, so real downloaders only need to implement the handful of
+// methods relevant to them.
+type NullDownloader struct{}
+
+var _ Downloader = &NullDownloader{}
+
+// SetContext set context
+func (NullDownloader) SetContext(_ context.Context) {}
+
+// GetRepoInfo returns a repository information
+func (NullDownloader) GetRepoInfo() (*Repository, error) {
+	return nil, nil
+}
+
+// GetTopics returns a repository's topics
+func (NullDownloader) GetTopics() ([]string, error) {
+	return nil, nil
+}
+
+// GetMilestones returns a repository's milestones
+func (NullDownloader) GetMilestones() ([]*Milestone, error) {
+	return nil, nil
+}
+
+// GetReleases returns a repository's releases
+func (NullDownloader) GetReleases() ([]*Release, error) {
+	return nil, nil
+}
+
+// GetLabels returns a repository's labels
+func (NullDownloader) GetLabels() ([]*Label, error) {
+	return nil, nil
+}
+
+// GetIssues returns a repository's issues
+func (NullDownloader) GetIssues(_, _ int) ([]*Issue, bool, error) {
+	return nil, true, nil
+}
+
+// GetComments returns a repository's comments
+func (NullDownloader) GetComments(_ Commentable) ([]*Comment, bool, error) {
+	return nil, true, nil
+}
+
+// GetPullRequests returns a repository's pull requests
+func (NullDownloader) GetPullRequests(_, _ int) ([]*PullRequest, bool, error) {
+	return nil, true, nil
+}
+
+// GetReviews returns a repository's pull request reviews
+func (NullDownloader) GetReviews(_ Reviewable) ([]*Review, error) {
+	return nil, nil
+}
+
+// Resume is a no-op: most downloaders have nothing paged to resume, or
+// nothing cheap enough to be worth resuming (e.g. a one-shot clone).
+func (NullDownloader) Resume(_ context.Context, _ *Checkpoint) error {
+	return nil
+}
+
+// Close is a no-op
+func (NullDownloader) Close() {}
@@ -0,0 +1,40 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migration
+
+import "time"
+
+// Issue is a standard issue information
+type Issue struct {
+	Number       int64
+	PosterID     int64
+	PosterName   string
+	Title        string
+	Content      string
+	Milestone    string
+	State        string
+	IsLocked     bool
+	Created      time.Time
+	Updated      time.Time
+	Closed       *time.Time
+	Labels       []*Label
+	Reactions    []*Reaction
+	Assignees    []string
+	ForeignIndex int64
+}
+
+// GetLocalIndex returns the local index, i.e. the issue number as it will
+// appear on the destination Gitea instance.
+func (i *Issue) GetLocalIndex() int64 {
+	return i.Number
+}
+
+// GetForeignIndex returns the index on the source forge, used to look up
+// this issue's children (comments, ...) from the downloader.
+func (i *Issue) GetForeignIndex() int64 {
+	if i.ForeignIndex != 0 {
+		return i.ForeignIndex
+	}
+	return i.Number
+}
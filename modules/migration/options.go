@@ -0,0 +1,24 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migration
+
+// MigrateOptions defines the permissions and options for one migration
+type MigrateOptions struct {
+	CloneAddr    string
+	AuthUsername string
+	AuthPassword string
+	AuthToken    string
+	LFS          bool
+
+	// TFVC forces the Azure DevOps downloader onto the TFVC-to-git
+	// conversion path instead of assuming the project is backed by a Git
+	// repository, for projects that carry both under the same name.
+	TFVC bool
+
+	// MigrateToRepoID is the destination repository's ID. When set, a
+	// Downloader that supports resuming uses it to load and persist its
+	// migration checkpoint, so a retried migration continues instead of
+	// restarting from scratch.
+	MigrateToRepoID int64
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migration
+
+import "time"
+
+// PullRequestBranch represents a branch a pull request is based on/off
+type PullRequestBranch struct {
+	Ref string
+	SHA string
+}
+
+// PullRequest defines a standard pull request information
+type PullRequest struct {
+	Number       int64
+	ForeignIndex int64
+	PosterID     int64
+	PosterName   string
+	Title        string
+	Content      string
+	State        string
+	IsLocked     bool
+	IsDraft      bool
+	Merged       bool
+	MergedTime   *time.Time
+	Created      time.Time
+	Updated      time.Time
+	Closed       *time.Time
+	Labels       []*Label
+	Reactions    []*Reaction
+	Assignees    []string
+	Head         PullRequestBranch
+	Base         PullRequestBranch
+}
+
+// GetLocalIndex returns the local index, i.e. the PR number as it will
+// appear on the destination Gitea instance.
+func (p *PullRequest) GetLocalIndex() int64 {
+	return p.Number
+}
+
+// GetForeignIndex returns the index on the source forge, used to look up
+// this pull request's children (comments, reviews, ...) from the downloader.
+func (p *PullRequest) GetForeignIndex() int64 {
+	if p.ForeignIndex != 0 {
+		return p.ForeignIndex
+	}
+	return p.Number
+}
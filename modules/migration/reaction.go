@@ -0,0 +1,11 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migration
+
+// Reaction represents a reaction to an issue/comment/pull request
+type Reaction struct {
+	UserID   int64
+	UserName string
+	Content  string
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migration
+
+import "time"
+
+// ReleaseAsset represents a release asset
+type ReleaseAsset struct {
+	Name        string
+	ContentType string
+	Size        int64
+	Created     time.Time
+	DownloadURL string
+}
+
+// Release represents a release
+type Release struct {
+	TagName         string
+	TargetCommitish string
+	Name            string
+	Body            string
+	Draft           bool
+	Prerelease      bool
+	Created         time.Time
+	Published       time.Time
+	PublisherID     int64
+	PublisherName   string
+	Assets          []*ReleaseAsset
+}
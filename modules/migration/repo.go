@@ -0,0 +1,15 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migration
+
+// Repository defines a standard repository information
+type Repository struct {
+	Name          string
+	Owner         string
+	IsPrivate     bool
+	Description   string
+	CloneURL      string
+	OriginalURL   string
+	DefaultBranch string
+}
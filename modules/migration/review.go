@@ -0,0 +1,42 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migration
+
+import "time"
+
+// ReviewState represents the state of a review
+type ReviewState string
+
+const (
+	ReviewStatePending        ReviewState = "PENDING"
+	ReviewStateApproved       ReviewState = "APPROVED"
+	ReviewStateRequestChanges ReviewState = "REQUEST_CHANGES"
+	ReviewStateComment        ReviewState = "COMMENT"
+)
+
+// ReviewComment represents a comment on a diff left as part of a Review
+type ReviewComment struct {
+	Content    string
+	TreePath   string
+	DiffHunk   string
+	Position   int
+	CommitID   string
+	PosterID   int64
+	PosterName string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Review is a standard review information
+type Review struct {
+	IssueIndex   int64
+	ReviewerID   int64
+	ReviewerName string
+	Official     bool
+	CommitID     string
+	Content      string
+	CreatedAt    time.Time
+	State        ReviewState
+	Comments     []*ReviewComment
+}
@@ -0,0 +1,21 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migration
+
+// Uploader uploads all the information of one repository
+type Uploader interface {
+	MaxBatchInsertSize(tp string) int
+	CreateRepo(repo *Repository, opts MigrateOptions) error
+	CreateTopics(topic ...string) error
+	CreateMilestones(milestones ...*Milestone) error
+	CreateLabels(labels ...*Label) error
+	CreateReleases(releases ...*Release) error
+	CreateIssues(issues ...*Issue) error
+	CreateComments(comments ...*Comment) error
+	CreatePullRequests(prs ...*PullRequest) error
+	CreateReviews(reviews ...*Review) error
+	Rollback() error
+	Finish() error
+	Close()
+}
@@ -0,0 +1,75 @@
+// Copyright 2016 The Gogs Authors. All rights reserved.
+// Copyright 2019 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// GitServiceType represents a git service
+type GitServiceType int
+
+// enumerate all GitServiceType
+const (
+	NotMigrated        GitServiceType = iota // 0 not migrated from external sites
+	PlainGitService                          // 1 plain git service
+	GithubService                            // 2 github.com
+	GiteaService                             // 3 gitea service
+	GitlabService                            // 4 gitlab service
+	GogsService                              // 5 gogs service
+	OneDevService                            // 6 onedev service
+	GiteeService                             // 7 gitee.com
+	AzureDevOpsService                       // 8 Azure DevOps Server/Service
+	GitBucketService                         // 9 GitBucket, a GitHub-compatible forge
+	F3Service                                // 10 any forge reachable through an F3 driver
+)
+
+// Name represents the service type's name
+func (gst GitServiceType) Name() string {
+	switch gst {
+	case GithubService:
+		return "github"
+	case GiteaService:
+		return "gitea"
+	case GitlabService:
+		return "gitlab"
+	case GogsService:
+		return "gogs"
+	case OneDevService:
+		return "onedev"
+	case GiteeService:
+		return "gitee"
+	case AzureDevOpsService:
+		return "azuredevops"
+	case GitBucketService:
+		return "gitbucket"
+	case F3Service:
+		return "f3"
+	default:
+		return ""
+	}
+}
+
+// Title represents the service type's proper title
+func (gst GitServiceType) Title() string {
+	switch gst {
+	case GithubService:
+		return "GitHub"
+	case GiteaService:
+		return "Gitea"
+	case GitlabService:
+		return "GitLab"
+	case GogsService:
+		return "Gogs"
+	case OneDevService:
+		return "OneDev"
+	case GiteeService:
+		return "Gitee"
+	case AzureDevOpsService:
+		return "Azure DevOps"
+	case GitBucketService:
+		return "GitBucket"
+	case F3Service:
+		return "F3"
+	default:
+		return ""
+	}
+}
@@ -6,8 +6,12 @@ package migrations
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"code.gitea.io/gitea/modules/log"
 	base "code.gitea.io/gitea/modules/migration"
@@ -16,7 +20,10 @@ import (
 	azuredevops "github.com/microsoft/azure-devops-go-api/azuredevops/v7"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/release"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/tfvc"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/workitemtracking"
 )
 
 var (
@@ -45,7 +52,23 @@ func (f *AzureDevOpsDownloaderV7Factory) New(ctx context.Context, opts base.Migr
 
 	log.Trace("Create azure devops downloader BaseURL: %s %s/%s", baseURL, oldOwner, oldName)
 
-	return NewAzureDevOpsDownloaderV7(ctx, baseURL, opts.AuthUsername, opts.AuthPassword, opts.AuthToken, oldOwner, oldName)
+	downloader, err := NewAzureDevOpsDownloaderV7(ctx, baseURL, opts.AuthUsername, opts.AuthPassword, opts.AuthToken, oldOwner, oldName, opts.TFVC)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.MigrateToRepoID > 0 {
+		downloader.migrateToRepoID = opts.MigrateToRepoID
+		checkpoint, err := LoadCheckpoint(ctx, opts.MigrateToRepoID)
+		if err != nil {
+			return nil, err
+		}
+		if err := downloader.Resume(ctx, checkpoint.toBaseCheckpoint()); err != nil {
+			return nil, err
+		}
+	}
+
+	return downloader, nil
 }
 
 // GitServiceType returns the type of git service
@@ -53,26 +76,119 @@ func (f *AzureDevOpsDownloaderV7Factory) GitServiceType() structs.GitServiceType
 	return structs.AzureDevOpsService
 }
 
+// azureDevOpsRate holds the back-off window dev.azure.com asked for in its
+// last TSTU (Total Subscription Throttling Unit) throttling response (a 429
+// or 503), so the next request waits it out. Requests are not slowed down
+// before the server starts rejecting them.
+type azureDevOpsRate struct {
+	retryAfter time.Duration
+}
+
 // AzureDevOpsDownloaderV7 implements a Downloader interface to get repository information
 // from azure devops via APIv7
-// TODO: implement rate limit handling
 type AzureDevOpsDownloaderV7 struct {
 	base.NullDownloader
-	ctx           context.Context
-	coreClient    core.Client
-	tfvcClient    tfvc.Client
-	baseURL       string
-	repoOwner     string
-	repoName      string
-	userName      string
-	password      string
-	maxPerPage    int
-	SkipReactions bool
-	SkipReviews   bool
-}
-
-// NewAzureDevOpsDownloaderV7 creates a azure devops Downloader via v7 API
-func NewAzureDevOpsDownloaderV7(ctx context.Context, baseURL, userName, password, token, repoOwner, repoName string) (*AzureDevOpsDownloaderV7, error) {
+	ctx            context.Context
+	coreClient     core.Client
+	gitClient      git.Client
+	tfvcClient     tfvc.Client
+	workItemClient workitemtracking.Client
+	releaseClient  release.Client
+	baseURL        string
+	repoOwner      string
+	repoName       string
+	repositoryID   string
+	userName       string
+	password       string
+	maxPerPage     int
+	rate           azureDevOpsRate
+	SkipReactions  bool
+	SkipReviews    bool
+
+	// IsTFVC is true when the project's history must be converted from TFVC
+	// via ImportTFVCToGit instead of being cloned directly. It is
+	// auto-detected in NewAzureDevOpsDownloaderV7 (no Git repository named
+	// repoName, and the project has TFVC branches), or can be forced via
+	// MigrateOptions.TFVC for projects that carry both a Git and a TFVC
+	// repository under the same name.
+	IsTFVC bool
+
+	// tfvcGitPath caches the local bare repo produced by ensureTFVCGitRepo
+	// so repeated GetRepoInfo calls do not redo the conversion.
+	tfvcGitPath string
+
+	// resume cursors, primed by Resume and added as a running offset to every
+	// page the paged Get* methods fetch, so a retried migration does not
+	// re-download pages that were already written by the uploader.
+	resumeIssuePage int
+	resumePullPage  int
+
+	// migrateToRepoID is the destination repo's ID, set by
+	// AzureDevOpsDownloaderV7Factory.New when MigrateOptions.MigrateToRepoID
+	// identifies a resumed migration. It keys the checkpoint persisted after
+	// each page so a later retry can pick up where this run left off.
+	migrateToRepoID int64
+}
+
+// Resume primes paging cursors from a previously persisted checkpoint so
+// GetIssues/GetPullRequests skip pages the uploader has already written.
+// Azure DevOps returns releases as a single unpaged list (see GetReleases),
+// so there is no release cursor to resume past.
+func (g *AzureDevOpsDownloaderV7) Resume(ctx context.Context, checkpoint *base.Checkpoint) error {
+	if checkpoint == nil {
+		return nil
+	}
+	g.resumeIssuePage = checkpoint.NextIssuePage
+	g.resumePullPage = checkpoint.NextPullPage
+	return nil
+}
+
+// Close clears the checkpoint for migrateToRepoID (once the migration runner
+// has finished uploading every entity, nothing is left to resume, and a
+// later re-migration of the same repo should start fresh rather than skip
+// ahead using a checkpoint left over from this completed run) and removes
+// the temporary bare repo ensureTFVCGitRepo created, if any.
+func (g *AzureDevOpsDownloaderV7) Close() {
+	if g.migrateToRepoID > 0 {
+		if err := ClearCheckpoint(g.ctx, g.migrateToRepoID); err != nil {
+			log.Error("azure devops downloader: clear checkpoint for repo %d: %v", g.migrateToRepoID, err)
+		}
+	}
+	if g.tfvcGitPath != "" {
+		if err := os.RemoveAll(g.tfvcGitPath); err != nil {
+			log.Error("azure devops downloader: remove temporary TFVC git repo %s: %v", g.tfvcGitPath, err)
+		}
+		g.tfvcGitPath = ""
+	}
+}
+
+// saveCheckpointPage persists nextPage into the checkpoint field selected by
+// setField (NextIssuePage or NextPullPage) for migrateToRepoID, preserving
+// the other cursor so the two collections resume independently. It is a
+// no-op when this downloader was not constructed for a resumable migration.
+func (g *AzureDevOpsDownloaderV7) saveCheckpointPage(nextPage int, setField func(*MigrationCheckpoint, int)) {
+	if g.migrateToRepoID <= 0 {
+		return
+	}
+	checkpoint, err := LoadCheckpoint(g.ctx, g.migrateToRepoID)
+	if err != nil {
+		log.Error("azure devops downloader: load checkpoint for repo %d: %v", g.migrateToRepoID, err)
+		return
+	}
+	if checkpoint == nil {
+		checkpoint = &MigrationCheckpoint{RepoID: g.migrateToRepoID}
+	}
+	setField(checkpoint, nextPage)
+	if err := SaveCheckpoint(g.ctx, checkpoint); err != nil {
+		log.Error("azure devops downloader: save checkpoint for repo %d: %v", g.migrateToRepoID, err)
+	}
+}
+
+// NewAzureDevOpsDownloaderV7 creates a azure devops Downloader via v7 API.
+// forceTFVC forces the TFVC-to-git conversion path (MigrateOptions.TFVC) for
+// projects that carry both a Git and a TFVC repository under the same name;
+// otherwise TFVC is only used when no Git repository is found.
+func NewAzureDevOpsDownloaderV7(ctx context.Context, baseURL, userName, password, token, repoOwner, repoName string, forceTFVC bool) (*AzureDevOpsDownloaderV7, error) {
 	downloader := AzureDevOpsDownloaderV7{
 		userName:   userName,
 		baseURL:    baseURL,
@@ -86,7 +202,7 @@ func NewAzureDevOpsDownloaderV7(ctx context.Context, baseURL, userName, password
 	connection := azuredevops.NewPatConnection(baseURL, token)
 	if token == "" && userName != "" && password != "" {
 		connection.AuthorizationString = azuredevops.CreateBasicAuthHeaderValue(userName, password)
-	} else {
+	} else if token == "" {
 		return nil, fmt.Errorf("no token or username/password provided")
 	}
 
@@ -96,10 +212,42 @@ func NewAzureDevOpsDownloaderV7(ctx context.Context, baseURL, userName, password
 	if err != nil {
 		return nil, err
 	}
+	downloader.gitClient, err = git.NewClient(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
 	downloader.tfvcClient, err = tfvc.NewClient(ctx, connection)
 	if err != nil {
 		return nil, err
 	}
+	downloader.workItemClient, err = workitemtracking.NewClient(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+	downloader.releaseClient, err = release.NewClient(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	if forceTFVC {
+		downloader.IsTFVC = true
+	} else {
+		repo, err := downloader.gitClient.GetRepository(ctx, git.GetRepositoryArgs{
+			RepositoryId: &repoName,
+			Project:      &repoOwner,
+		})
+		if err != nil || repo == nil {
+			// No Git repository under this project name; fall back to
+			// auto-detecting a TFVC one with the same name before giving up.
+			isTFVC, tfvcErr := downloader.isTFVCProject()
+			if tfvcErr == nil && isTFVC {
+				downloader.IsTFVC = true
+			}
+		} else if repo.Id != nil {
+			downloader.repositoryID = repo.Id.String()
+		}
+	}
+
 	return &downloader, nil
 }
 
@@ -124,44 +272,755 @@ func (g *AzureDevOpsDownloaderV7) getProjectID() string {
 	return fmt.Sprintf("%v/%v", g.repoOwner, g.repoName)
 }
 
+// waitAndRetry blocks for whatever retry-after window azure devops last
+// reported before letting the caller issue another request. TSTU throttling
+// on dev.azure.com comes back as a 429/503 with a Retry-After header rather
+// than an X-RateLimit-Remaining style budget, so there is nothing to do
+// until that window elapses.
+func (g *AzureDevOpsDownloaderV7) waitAndRetry() {
+	if g.rate.retryAfter <= 0 {
+		return
+	}
+	log.Info("azure devops downloader: sleeping %s to respect TSTU throttling", g.rate.retryAfter)
+	select {
+	case <-g.ctx.Done():
+	case <-time.After(g.rate.retryAfter):
+	}
+	g.rate.retryAfter = 0
+}
+
+// setRate records how long to back off for based on an azuredevops.WrappedError,
+// returning true if the error was a throttling response that should be retried.
+func (g *AzureDevOpsDownloaderV7) setRate(err error) bool {
+	wrappedErr, ok := err.(azuredevops.WrappedError)
+	if !ok {
+		return false
+	}
+
+	statusCode := 0
+	if wrappedErr.StatusCode != nil {
+		statusCode = *wrappedErr.StatusCode
+	}
+	if statusCode != http.StatusTooManyRequests && statusCode != http.StatusServiceUnavailable {
+		return false
+	}
+
+	retryAfter := 30 * time.Second
+	if wrappedErr.AdditionalProperties != nil {
+		if v, ok := wrappedErr.AdditionalProperties["Retry-After"]; ok {
+			if s, ok := v.(string); ok {
+				if seconds, err := strconv.Atoi(s); err == nil {
+					retryAfter = time.Duration(seconds) * time.Second
+				}
+			}
+		}
+	}
+	g.rate.retryAfter = retryAfter
+	return true
+}
+
+// withRetry runs fn, retrying once TSTU throttling backoff has elapsed
+// whenever azure devops responds with a throttling error.
+func (g *AzureDevOpsDownloaderV7) withRetry(fn func() error) error {
+	for {
+		g.waitAndRetry()
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if !g.setRate(err) {
+			return err
+		}
+	}
+}
+
 // GetRepoInfo returns a repository information
 func (g *AzureDevOpsDownloaderV7) GetRepoInfo() (*base.Repository, error) {
 	pjID := g.getProjectID()
-	coreClient, err := core.NewClient(g.getConnection())
+
+	var pj *core.TeamProject
+	err := g.withRetry(func() error {
+		var err error
+		pj, err = g.coreClient.GetProject(g.ctx, core.GetProjectArgs{ProjectId: &pjID})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	pj, err := coreClient.GetProject(g.ctx, core.GetProjectArgs{ProjectId: &pjID})
+
+	description := ""
+	if pj.Description != nil {
+		description = *pj.Description
+	}
+
+	if g.IsTFVC {
+		gitPath, err := g.ensureTFVCGitRepo()
+		if err != nil {
+			return nil, err
+		}
+		return &base.Repository{
+			Owner:         g.repoOwner,
+			Name:          *pj.Name,
+			IsPrivate:     pj.Visibility != nil && *pj.Visibility == core.ProjectVisibilityValues.Private,
+			Description:   description,
+			OriginalURL:   g.baseURL,
+			CloneURL:      "file://" + gitPath,
+			DefaultBranch: "main",
+		}, nil
+	}
+
+	var repo *git.GitRepository
+	err = g.withRetry(func() error {
+		var err error
+		repo, err = g.gitClient.GetRepository(g.ctx, git.GetRepositoryArgs{
+			RepositoryId: &g.repoName,
+			Project:      &g.repoOwner,
+		})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	g.setRate(&resp.Rate)
+
+	defaultBranch := ""
+	if repo.DefaultBranch != nil {
+		defaultBranch = strings.TrimPrefix(*repo.DefaultBranch, "refs/heads/")
+	}
 
 	// convert azure devops project repo to stand Repo
 	return &base.Repository{
 		Owner:         g.repoOwner,
 		Name:          *pj.Name,
-		IsPrivate:     pj.Visibility == &core.ProjectVisibilityValues.Private,
-		Description:   *pj.Description,
-		OriginalURL:   *pj.Url,
-		CloneURL:      *pj.Url,
-		DefaultBranch: "", // TODO
+		IsPrivate:     pj.Visibility != nil && *pj.Visibility == core.ProjectVisibilityValues.Private,
+		Description:   description,
+		OriginalURL:   *repo.WebUrl,
+		CloneURL:      *repo.RemoteUrl,
+		DefaultBranch: defaultBranch,
 	}, nil
 }
 
 // GetTopics return topics
-// TODO
+func (g *AzureDevOpsDownloaderV7) GetTopics() ([]string, error) {
+	pjID := g.getProjectID()
+
+	var props *core.ProjectProperties
+	err := g.withRetry(func() error {
+		var err error
+		props, err = g.coreClient.GetProjectProperties(g.ctx, core.GetProjectPropertiesArgs{ProjectId: stringToUUIDPtr(pjID)})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	topics := make([]string, 0, len(*props))
+	for _, prop := range *props {
+		if prop.Name != nil && *prop.Name == "System.Topics" {
+			if value, ok := prop.Value.(string); ok {
+				for _, topic := range strings.Split(value, ",") {
+					topic = strings.TrimSpace(topic)
+					if topic != "" {
+						topics = append(topics, topic)
+					}
+				}
+			}
+		}
+	}
+	return topics, nil
+}
+
+// GetMilestones returns milestones, which azure devops models as iterations
+func (g *AzureDevOpsDownloaderV7) GetMilestones() ([]*base.Milestone, error) {
+	pjID := g.getProjectID()
 
-// GetMilestones returns milestones
-// TODO
+	var iterations *[]workitemtracking.WorkItemClassificationNode
+	err := g.withRetry(func() error {
+		var err error
+		iterations, err = g.workItemClient.GetClassificationNode(g.ctx, workitemtracking.GetClassificationNodeArgs{
+			Project:        &pjID,
+			StructureGroup: &workitemtracking.TreeStructureGroupValues.Iterations,
+			Depth:          intPtr(2),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
-// GetLabels returns labels
+	milestones := make([]*base.Milestone, 0, len(*iterations))
+	for _, iteration := range *iterations {
+		if iteration.Name == nil {
+			continue
+		}
+		milestone := &base.Milestone{
+			Title: *iteration.Name,
+			State: "active",
+		}
+		if iteration.Attributes != nil {
+			if iteration.Attributes.StartDate != nil {
+				milestone.Created = iteration.Attributes.StartDate.Time
+			}
+			if iteration.Attributes.FinishDate != nil {
+				finish := iteration.Attributes.FinishDate.Time
+				milestone.Updated = &finish
+				if finish.Before(time.Now()) {
+					milestone.State = "closed"
+					milestone.Closed = &finish
+				}
+			}
+		}
+		milestones = append(milestones, milestone)
+	}
+	return milestones, nil
+}
+
+// GetLabels returns labels, which on azure devops are modelled as TFVC labels
 func (g *AzureDevOpsDownloaderV7) GetLabels() ([]*base.Label, error) {
-	g.tfvcClient.GetLabels(g.ctx, tfvc.GetLabelsArgs{
-		RequestData: &git.TfvcLabelRequestData{
-			Owner:      &g.repoOwner,
-			Repository: &g.repoName,
-		},
+	var labels *[]tfvc.TfvcLabel
+	err := g.withRetry(func() error {
+		var err error
+		labels, err = g.tfvcClient.GetLabels(g.ctx, tfvc.GetLabelsArgs{
+			RequestData: &tfvc.TfvcLabelRequestData{
+				Owner: &g.repoOwner,
+				Name:  &g.repoName,
+			},
+		})
+		return err
 	})
-	// TODO
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*base.Label, 0, len(*labels))
+	for _, label := range *labels {
+		if label.Name == nil {
+			continue
+		}
+		result = append(result, &base.Label{
+			Name:  *label.Name,
+			Color: "d9534f",
+		})
+	}
+	return result, nil
+}
+
+// GetReleases returns releases
+func (g *AzureDevOpsDownloaderV7) GetReleases() ([]*base.Release, error) {
+	pjID := g.getProjectID()
+
+	var releases *[]release.Release
+	err := g.withRetry(func() error {
+		var err error
+		releases, err = g.releaseClient.GetReleases(g.ctx, release.GetReleasesArgs{Project: &pjID})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*base.Release, 0, len(*releases))
+	for _, rel := range *releases {
+		converted := &base.Release{}
+		if rel.Name != nil {
+			converted.Name = *rel.Name
+		}
+		if rel.Description != nil {
+			converted.Body = *rel.Description
+		}
+		if rel.CreatedOn != nil {
+			converted.Created = rel.CreatedOn.Time
+			converted.Published = rel.CreatedOn.Time
+		}
+		if rel.CreatedBy != nil {
+			if rel.CreatedBy.DisplayName != nil {
+				converted.PublisherName = *rel.CreatedBy.DisplayName
+			}
+			if rel.CreatedBy.Id != nil {
+				converted.PublisherID, _ = strconv.ParseInt(*rel.CreatedBy.Id, 10, 64)
+			}
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+// GetIssues returns work items mapped onto base.Issue, paged via WIQL + $top/$skip
+func (g *AzureDevOpsDownloaderV7) GetIssues(page, perPage int) ([]*base.Issue, bool, error) {
+	if g.resumeIssuePage > 1 {
+		page += g.resumeIssuePage - 1
+	}
+
+	pjID := g.getProjectID()
+
+	wiql := fmt.Sprintf("SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = '%s' ORDER BY [System.Id] ASC", escapeWiqlString(g.repoOwner))
+
+	var queryResult *workitemtracking.WorkItemQueryResult
+	err := g.withRetry(func() error {
+		var err error
+		queryResult, err = g.workItemClient.QueryByWiql(g.ctx, workitemtracking.QueryByWiqlArgs{
+			Wiql:    &workitemtracking.Wiql{Query: &wiql},
+			Project: &pjID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	allRefs := *queryResult.WorkItems
+	start := (page - 1) * perPage
+	if start >= len(allRefs) {
+		return []*base.Issue{}, true, nil
+	}
+	end := start + perPage
+	isEnd := end >= len(allRefs)
+	if end > len(allRefs) {
+		end = len(allRefs)
+	}
+
+	ids := make([]int, 0, end-start)
+	for _, ref := range allRefs[start:end] {
+		if ref.Id != nil {
+			ids = append(ids, *ref.Id)
+		}
+	}
+
+	var items *[]workitemtracking.WorkItem
+	err = g.withRetry(func() error {
+		var err error
+		items, err = g.workItemClient.GetWorkItems(g.ctx, workitemtracking.GetWorkItemsArgs{
+			Ids:     &ids,
+			Expand:  &workitemtracking.WorkItemExpandValues.All,
+			Project: &pjID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	issues := make([]*base.Issue, 0, len(*items))
+	for _, item := range *items {
+		issue, err := g.convertWorkItem(&item)
+		if err != nil {
+			return nil, false, err
+		}
+		issues = append(issues, issue)
+	}
+
+	if !isEnd {
+		g.saveCheckpointPage(page+1, func(c *MigrationCheckpoint, p int) { c.NextIssuePage = p })
+	}
+	return issues, isEnd, nil
+}
+
+func (g *AzureDevOpsDownloaderV7) convertWorkItem(item *workitemtracking.WorkItem) (*base.Issue, error) {
+	fields := *item.Fields
+
+	issue := &base.Issue{
+		Number:  int64(*item.Id),
+		State:   "open",
+		Content: stringField(fields, "System.Description"),
+		Title:   stringField(fields, "System.Title"),
+	}
+
+	if state := stringField(fields, "System.State"); state == "Closed" || state == "Resolved" || state == "Done" {
+		issue.State = "closed"
+	}
+
+	if created, ok := dateField(fields, "System.CreatedDate"); ok {
+		issue.Created = created
+	}
+	if updated, ok := dateField(fields, "System.ChangedDate"); ok {
+		issue.Updated = updated
+	}
+
+	if poster, ok := identityField(fields, "System.CreatedBy"); ok {
+		issue.PosterName = poster.name
+		issue.PosterID = poster.id
+	}
+	if assignee, ok := identityField(fields, "System.AssignedTo"); ok {
+		issue.Assignees = []string{assignee.name}
+	}
+
+	if iterationPath := stringField(fields, "System.IterationPath"); iterationPath != "" {
+		parts := strings.Split(iterationPath, "\\")
+		issue.Milestone = parts[len(parts)-1]
+	}
+
+	if tags := stringField(fields, "System.Tags"); tags != "" {
+		for _, tag := range strings.Split(tags, ";") {
+			tag = strings.TrimSpace(tag)
+			if tag != "" {
+				issue.Labels = append(issue.Labels, &base.Label{Name: tag, Color: "d9534f"})
+			}
+		}
+	}
+
+	return issue, nil
+}
+
+// GetComments returns comments for a work item (issue), or the general
+// discussion comments on a pull request. Work items and pull requests use
+// completely different foreign-index namespaces and APIs, so which one
+// commentable is determines which client this fetches from.
+func (g *AzureDevOpsDownloaderV7) GetComments(commentable base.Commentable) ([]*base.Comment, bool, error) {
+	if pr, ok := commentable.(*base.PullRequest); ok {
+		return g.getPullRequestComments(pr)
+	}
+	return g.getWorkItemComments(commentable)
+}
+
+func (g *AzureDevOpsDownloaderV7) getWorkItemComments(commentable base.Commentable) ([]*base.Comment, bool, error) {
+	workItemID := int(commentable.GetForeignIndex())
+	if workItemID == 0 {
+		return nil, true, nil
+	}
+
+	pjID := g.getProjectID()
+
+	var comments *workitemtracking.CommentList
+	err := g.withRetry(func() error {
+		var err error
+		comments, err = g.workItemClient.GetComments(g.ctx, workitemtracking.GetCommentsArgs{
+			Project:    &pjID,
+			WorkItemId: &workItemID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, true, err
+	}
+
+	result := make([]*base.Comment, 0, len(*comments.Comments))
+	for _, comment := range *comments.Comments {
+		c := &base.Comment{
+			IssueIndex: commentable.GetLocalIndex(),
+			Content:    safeString(comment.Text),
+		}
+		if comment.CreatedBy != nil && comment.CreatedBy.DisplayName != nil {
+			c.PosterName = *comment.CreatedBy.DisplayName
+		}
+		if comment.CreatedDate != nil {
+			c.Created = comment.CreatedDate.Time
+			c.Updated = comment.CreatedDate.Time
+		}
+		result = append(result, c)
+	}
+	return result, true, nil
+}
+
+// getPullRequestComments returns the general discussion comments on pr's
+// threads, i.e. threads with no ThreadContext. Threads anchored to a diff
+// position are surfaced as review comments by GetReviews instead.
+func (g *AzureDevOpsDownloaderV7) getPullRequestComments(pr *base.PullRequest) ([]*base.Comment, bool, error) {
+	prID := int(pr.GetForeignIndex())
+
+	var threads *[]git.GitPullRequestCommentThread
+	err := g.withRetry(func() error {
+		var err error
+		threads, err = g.gitClient.GetThreads(g.ctx, git.GetThreadsArgs{
+			RepositoryId:  &g.repoName,
+			PullRequestId: &prID,
+			Project:       &g.repoOwner,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, true, err
+	}
+
+	var result []*base.Comment
+	for _, thread := range *threads {
+		if thread.ThreadContext != nil || thread.Comments == nil {
+			continue
+		}
+		for _, comment := range *thread.Comments {
+			c := &base.Comment{
+				IssueIndex: pr.GetLocalIndex(),
+				Content:    safeString(comment.Content),
+			}
+			if comment.Author != nil && comment.Author.DisplayName != nil {
+				c.PosterName = *comment.Author.DisplayName
+			}
+			if comment.PublishedDate != nil {
+				c.Created = comment.PublishedDate.Time
+				c.Updated = comment.PublishedDate.Time
+			}
+			result = append(result, c)
+		}
+	}
+	return result, true, nil
+}
+
+// GetPullRequests returns pull requests, paged via $top/$skip
+func (g *AzureDevOpsDownloaderV7) GetPullRequests(page, perPage int) ([]*base.PullRequest, bool, error) {
+	if g.resumePullPage > 1 {
+		page += g.resumePullPage - 1
+	}
+
+	top := perPage
+	skip := (page - 1) * perPage
+
+	var prs *[]git.GitPullRequest
+	err := g.withRetry(func() error {
+		var err error
+		prs, err = g.gitClient.GetPullRequests(g.ctx, git.GetPullRequestsArgs{
+			RepositoryId: &g.repoName,
+			Project:      &g.repoOwner,
+			Top:          &top,
+			Skip:         &skip,
+			SearchCriteria: &git.GitPullRequestSearchCriteria{
+				Status: &git.PullRequestStatusValues.All,
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	isEnd := len(*prs) < perPage
+	result := make([]*base.PullRequest, 0, len(*prs))
+	for _, pr := range *prs {
+		converted, err := g.convertPullRequest(&pr)
+		if err != nil {
+			return nil, false, err
+		}
+		result = append(result, converted)
+	}
+
+	if !isEnd {
+		g.saveCheckpointPage(page+1, func(c *MigrationCheckpoint, p int) { c.NextPullPage = p })
+	}
+	return result, isEnd, nil
+}
+
+func (g *AzureDevOpsDownloaderV7) convertPullRequest(pr *git.GitPullRequest) (*base.PullRequest, error) {
+	number := int64(*pr.PullRequestId)
+
+	result := &base.PullRequest{
+		Number:       number,
+		Title:        safeString(pr.Title),
+		Content:      safeString(pr.Description),
+		State:        "open",
+		ForeignIndex: number,
+	}
+
+	switch {
+	case pr.Status != nil && *pr.Status == git.PullRequestStatusValues.Completed:
+		result.State = "closed"
+		result.Merged = true
+	case pr.Status != nil && *pr.Status == git.PullRequestStatusValues.Abandoned:
+		result.State = "closed"
+	}
+
+	if pr.CreationDate != nil {
+		result.Created = pr.CreationDate.Time
+		result.Updated = pr.CreationDate.Time
+	}
+	if pr.ClosedDate != nil {
+		closed := pr.ClosedDate.Time
+		result.Closed = &closed
+	}
+	if pr.CreatedBy != nil {
+		if pr.CreatedBy.DisplayName != nil {
+			result.PosterName = *pr.CreatedBy.DisplayName
+		}
+		if pr.CreatedBy.Id != nil {
+			result.PosterID, _ = strconv.ParseInt(*pr.CreatedBy.Id, 10, 64)
+		}
+	}
+	if pr.LastMergeSourceCommit != nil && pr.LastMergeSourceCommit.CommitId != nil {
+		result.Head.SHA = *pr.LastMergeSourceCommit.CommitId
+	}
+	if pr.LastMergeTargetCommit != nil && pr.LastMergeTargetCommit.CommitId != nil {
+		result.Base.SHA = *pr.LastMergeTargetCommit.CommitId
+	}
+	if pr.SourceRefName != nil {
+		result.Head.Ref = strings.TrimPrefix(*pr.SourceRefName, "refs/heads/")
+	}
+	if pr.TargetRefName != nil {
+		result.Base.Ref = strings.TrimPrefix(*pr.TargetRefName, "refs/heads/")
+	}
+
+	for _, reviewer := range safeReviewers(pr.Reviewers) {
+		if reviewer.DisplayName != nil {
+			result.Assignees = append(result.Assignees, *reviewer.DisplayName)
+		}
+	}
+
+	return result, nil
+}
+
+// GetReviews returns reviews (reviewer votes) and review comments (thread comments)
+// for a pull request.
+func (g *AzureDevOpsDownloaderV7) GetReviews(reviewable base.Reviewable) ([]*base.Review, error) {
+	if g.SkipReviews {
+		return nil, nil
+	}
+
+	prID := int(reviewable.GetForeignIndex())
+
+	var reviewers *[]git.IdentityRefWithVote
+	err := g.withRetry(func() error {
+		var err error
+		reviewers, err = g.gitClient.GetPullRequestReviewers(g.ctx, git.GetPullRequestReviewersArgs{
+			RepositoryId:  &g.repoName,
+			PullRequestId: &prID,
+			Project:       &g.repoOwner,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := make([]*base.Review, 0, len(*reviewers))
+	for _, reviewer := range *reviewers {
+		review := &base.Review{
+			IssueIndex: reviewable.GetLocalIndex(),
+			Official:   reviewer.IsRequired != nil && *reviewer.IsRequired,
+			CreatedAt:  time.Now(),
+		}
+		if reviewer.DisplayName != nil {
+			review.ReviewerName = *reviewer.DisplayName
+		}
+		if reviewer.Id != nil {
+			review.ReviewerID, _ = strconv.ParseInt(*reviewer.Id, 10, 64)
+		}
+		if reviewer.Vote != nil {
+			switch {
+			case *reviewer.Vote >= 5:
+				review.State = base.ReviewStateApproved
+			case *reviewer.Vote <= -5:
+				review.State = base.ReviewStateRequestChanges
+			case *reviewer.Vote < 0:
+				review.State = base.ReviewStateComment
+			default:
+				review.State = base.ReviewStatePending
+			}
+		}
+		reviews = append(reviews, review)
+	}
+
+	var threads *[]git.GitPullRequestCommentThread
+	err = g.withRetry(func() error {
+		var err error
+		threads, err = g.gitClient.GetThreads(g.ctx, git.GetThreadsArgs{
+			RepositoryId:  &g.repoName,
+			PullRequestId: &prID,
+			Project:       &g.repoOwner,
+		})
+		return err
+	})
+	if err != nil {
+		return reviews, err
+	}
+
+	for _, thread := range *threads {
+		// threads with no ThreadContext are general discussion, already
+		// returned by GetComments
+		if thread.ThreadContext == nil || thread.Comments == nil || len(*thread.Comments) == 0 {
+			continue
+		}
+		review := &base.Review{
+			IssueIndex: reviewable.GetLocalIndex(),
+			State:      base.ReviewStateComment,
+			CreatedAt:  time.Now(),
+		}
+		for _, comment := range *thread.Comments {
+			reviewComment := &base.ReviewComment{
+				Content: safeString(comment.Content),
+			}
+			if comment.Author != nil && comment.Author.DisplayName != nil {
+				reviewComment.PosterName = *comment.Author.DisplayName
+			}
+			if thread.ThreadContext != nil && thread.ThreadContext.FilePath != nil {
+				reviewComment.TreePath = *thread.ThreadContext.FilePath
+			}
+			if comment.PublishedDate != nil {
+				reviewComment.CreatedAt = comment.PublishedDate.Time
+				reviewComment.UpdatedAt = comment.PublishedDate.Time
+			}
+			review.Comments = append(review.Comments, reviewComment)
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, nil
+}
+
+func safeReviewers(reviewers *[]webapi.IdentityRefWithVote) []webapi.IdentityRefWithVote {
+	if reviewers == nil {
+		return nil
+	}
+	return *reviewers
+}
+
+func safeString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func stringField(fields map[string]interface{}, key string) string {
+	if v, ok := fields[key]; ok {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+func dateField(fields map[string]interface{}, key string) (time.Time, bool) {
+	if v, ok := fields[key]; ok {
+		if s, ok := v.(string); ok {
+			t, err := time.Parse(time.RFC3339, s)
+			if err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+type azureIdentity struct {
+	name string
+	id   int64
+}
+
+func identityField(fields map[string]interface{}, key string) (azureIdentity, bool) {
+	v, ok := fields[key]
+	if !ok {
+		return azureIdentity{}, false
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return azureIdentity{}, false
+	}
+	identity := azureIdentity{}
+	if name, ok := m["displayName"].(string); ok {
+		identity.name = name
+	}
+	if id, ok := m["id"].(string); ok {
+		identity.id, _ = strconv.ParseInt(id, 10, 64)
+	}
+	return identity, true
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func stringToUUIDPtr(s string) *azuredevops.UUID {
+	id := azuredevops.UUID(s)
+	return &id
+}
+
+// escapeWiqlString escapes a value for embedding in a WIQL string literal by
+// doubling single quotes, the same way WIQL (and the T-SQL it mirrors) escapes them.
+func escapeWiqlString(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
 }
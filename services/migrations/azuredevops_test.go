@@ -26,7 +26,7 @@ func TestAzureDevOpsDownloadRepo(t *testing.T) {
 		t.Skipf("Can't access test repo, skipping %s", t.Name())
 	}
 
-	downloader, err := NewAzureDevOpsDownloaderV7(context.Background(), "https://dev.azure.com", "", "", azureDevOpsPersonalAccessToken, "go-gitea", "test_repo")
+	downloader, err := NewAzureDevOpsDownloaderV7(context.Background(), "https://dev.azure.com", "", "", azureDevOpsPersonalAccessToken, "go-gitea", "test_repo", false)
 	if err != nil {
 		t.Fatalf("NewAzureDevOpsDownloaderV7 is nil: %v", err)
 	}
@@ -210,3 +210,9 @@ func TestAzureDevOpsDownloadRepo(t *testing.T) {
 		},
 	}, issues)
 }
+
+func TestEscapeWiqlString(t *testing.T) {
+	assert.EqualValues(t, "go-gitea", escapeWiqlString("go-gitea"))
+	assert.EqualValues(t, "O''Brien", escapeWiqlString("O'Brien"))
+	assert.EqualValues(t, "''''", escapeWiqlString("''"))
+}
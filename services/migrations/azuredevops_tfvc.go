@@ -0,0 +1,371 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/log"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/tfvc"
+)
+
+// isTFVCProject reports whether the azure devops project backing this
+// downloader is a TFVC project rather than a Git one. Azure DevOps projects
+// created before Git support (or explicitly configured for TFVC) expose
+// their history through the tfvc client instead of git.GetRepository.
+func (g *AzureDevOpsDownloaderV7) isTFVCProject() (bool, error) {
+	var branches *[]tfvc.TfvcBranch
+	err := g.withRetry(func() error {
+		var err error
+		branches, err = g.tfvcClient.GetBranches(g.ctx, tfvc.GetBranchesArgs{
+			Project: &g.repoOwner,
+		})
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return branches != nil && len(*branches) > 0, nil
+}
+
+// tfvcBranchMapping maps a TFVC server path under $/<project> to the git
+// branch it should land on. The project's main branch (the root $/Project
+// path) always maps to refs/heads/main; any additional TFVC branches found
+// under the project are mapped to a branch of the same name.
+type tfvcBranchMapping struct {
+	serverPath string
+	branchRef  string
+}
+
+func (g *AzureDevOpsDownloaderV7) tfvcBranchMappings() ([]tfvcBranchMapping, error) {
+	rootPath := fmt.Sprintf("$/%s", g.repoOwner)
+
+	var branches *[]tfvc.TfvcBranch
+	err := g.withRetry(func() error {
+		var err error
+		branches, err = g.tfvcClient.GetBranches(g.ctx, tfvc.GetBranchesArgs{
+			Project: &g.repoOwner,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mappings := []tfvcBranchMapping{{serverPath: rootPath, branchRef: "refs/heads/main"}}
+	for _, branch := range *branches {
+		if branch.Path == nil || *branch.Path == rootPath {
+			continue
+		}
+		name := strings.TrimPrefix(*branch.Path, rootPath+"/")
+		mappings = append(mappings, tfvcBranchMapping{
+			serverPath: *branch.Path,
+			branchRef:  "refs/heads/" + name,
+		})
+	}
+
+	// TFVC path search is prefix-based, so querying the root path returns
+	// every changeset under every branch folder too, not just trunk's own
+	// history. Importing deepest (most specific) paths first and having
+	// ImportTFVCToGit skip changesets already claimed by an earlier, more
+	// specific mapping keeps each branch folder's history off of main.
+	sort.Slice(mappings, func(i, j int) bool {
+		return len(mappings[i].serverPath) > len(mappings[j].serverPath)
+	})
+	return mappings, nil
+}
+
+// ImportTFVCToGit walks the TFVC changeset history for the project and
+// streams it into the bare git repository at gitPath via `git fast-import`,
+// honoring the project's branch layout and converting TFVC labels into git
+// tags. It is used instead of the regular git clone path when
+// MigrateOptions.TFVC (or project auto-detection) indicates a TFVC source.
+func (g *AzureDevOpsDownloaderV7) ImportTFVCToGit(ctx context.Context, gitPath string) error {
+	mappings, err := g.tfvcBranchMappings()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "fast-import", "--quiet")
+	cmd.Dir = gitPath
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(stdin)
+	markByChangeset := map[int]int{}
+	claimed := map[int]bool{}
+	mark := 0
+
+	for _, mapping := range mappings {
+		top := 1000
+		skip := 0
+		for {
+			var changesets *[]tfvc.TfvcChangesetRef
+			skipCopy, topCopy := skip, top
+			err := g.withRetry(func() error {
+				var err error
+				changesets, err = g.tfvcClient.GetChangesets(g.ctx, tfvc.GetChangesetsArgs{
+					Project: &g.repoOwner,
+					Top:     &topCopy,
+					Skip:    &skipCopy,
+					SearchCriteria: &tfvc.TfvcChangesetSearchCriteria{
+						Path: &mapping.serverPath,
+					},
+				})
+				return err
+			})
+			if err != nil {
+				_ = stdin.Close()
+				_ = cmd.Wait()
+				return err
+			}
+			if changesets == nil || len(*changesets) == 0 {
+				break
+			}
+
+			for _, cs := range *changesets {
+				// mappings are walked deepest-path-first, so a changeset
+				// already claimed by a more specific branch folder belongs
+				// to that branch, not this (shallower) one.
+				if claimed[*cs.ChangesetId] {
+					continue
+				}
+				claimed[*cs.ChangesetId] = true
+				mark++
+				markByChangeset[*cs.ChangesetId] = mark
+				if err := g.writeFastImportCommit(w, mapping.branchRef, mark, &cs); err != nil {
+					_ = stdin.Close()
+					_ = cmd.Wait()
+					return err
+				}
+			}
+
+			if len(*changesets) < top {
+				break
+			}
+			skip += top
+		}
+	}
+
+	if err := g.writeFastImportTags(w, markByChangeset); err != nil {
+		_ = stdin.Close()
+		_ = cmd.Wait()
+		return err
+	}
+
+	if err := w.Flush(); err != nil {
+		_ = stdin.Close()
+		_ = cmd.Wait()
+		return err
+	}
+	if err := stdin.Close(); err != nil {
+		return err
+	}
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("git fast-import: %w", err)
+	}
+
+	log.Info("azure devops downloader: imported %d TFVC changesets for %s/%s", mark, g.repoOwner, g.repoName)
+	return nil
+}
+
+func (g *AzureDevOpsDownloaderV7) writeFastImportCommit(w *bufio.Writer, branchRef string, mark int, cs *tfvc.TfvcChangesetRef) error {
+	author := "unknown <unknown@localhost>"
+	if cs.Author != nil && cs.Author.DisplayName != nil {
+		author = fmt.Sprintf("%s <%s@localhost>", *cs.Author.DisplayName, strings.ToLower(strings.ReplaceAll(*cs.Author.DisplayName, " ", ".")))
+	}
+
+	when := time.Now()
+	if cs.CreatedDate != nil {
+		when = cs.CreatedDate.Time
+	}
+
+	message := ""
+	if cs.Comment != nil {
+		message = *cs.Comment
+	}
+
+	var changes *[]tfvc.TfvcChange
+	err := g.withRetry(func() error {
+		var err error
+		changes, err = g.tfvcClient.GetChangesetChanges(g.ctx, tfvc.GetChangesetChangesArgs{
+			Id: cs.ChangesetId,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "commit %s\n", branchRef)
+	fmt.Fprintf(w, "mark :%d\n", mark)
+	fmt.Fprintf(w, "committer %s %d +0000\n", author, when.Unix())
+	fmt.Fprintf(w, "data %d\n%s\n", len(message), message)
+
+	for _, change := range *changes {
+		if change.Item == nil {
+			continue
+		}
+		item, ok := (*change.Item).(map[string]interface{})
+		if !ok {
+			continue
+		}
+		serverPath, _ := item["path"].(string)
+		if serverPath == "" {
+			continue
+		}
+		relPath := strings.TrimPrefix(serverPath, fmt.Sprintf("$/%s/", g.repoOwner))
+
+		changeType := ""
+		if change.ChangeType != nil {
+			changeType = string(*change.ChangeType)
+		}
+
+		if strings.Contains(changeType, "delete") {
+			fmt.Fprintf(w, "D %s\n", relPath)
+			continue
+		}
+
+		if isFolder, _ := item["isFolder"].(bool); isFolder {
+			continue
+		}
+
+		content, err := g.fetchTFVCItemContent(serverPath, *cs.ChangesetId)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "M 100644 inline %s\n", relPath)
+		fmt.Fprintf(w, "data %d\n", len(content))
+		w.Write(content)
+		w.WriteString("\n")
+	}
+
+	return nil
+}
+
+// fetchTFVCItemContent downloads the actual file bytes for path as of
+// changesetID. TfvcChange.Item only carries metadata (path, item type, …),
+// not the file content, so every change requires a dedicated content fetch.
+func (g *AzureDevOpsDownloaderV7) fetchTFVCItemContent(serverPath string, changesetID int) ([]byte, error) {
+	version := strconv.Itoa(changesetID)
+
+	var reader io.ReadCloser
+	err := g.withRetry(func() error {
+		var err error
+		reader, err = g.tfvcClient.GetItemContent(g.ctx, tfvc.GetItemContentArgs{
+			Path: &serverPath,
+			VersionDescriptor: &tfvc.TfvcVersionDescriptor{
+				Version:     &version,
+				VersionType: &tfvc.TfvcVersionTypeValues.Changeset,
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+func (g *AzureDevOpsDownloaderV7) writeFastImportTags(w *bufio.Writer, markByChangeset map[int]int) error {
+	var labels *[]tfvc.TfvcLabel
+	err := g.withRetry(func() error {
+		var err error
+		labels, err = g.tfvcClient.GetLabels(g.ctx, tfvc.GetLabelsArgs{
+			RequestData: &tfvc.TfvcLabelRequestData{
+				Owner: &g.repoOwner,
+				Name:  &g.repoName,
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, label := range *labels {
+		if label.Name == nil || label.LabelScope == nil {
+			continue
+		}
+
+		var items *[]tfvc.TfvcItem
+		err := g.withRetry(func() error {
+			var err error
+			items, err = g.tfvcClient.GetLabelItems(g.ctx, tfvc.GetLabelItemsArgs{
+				LabelId: label.Id,
+			})
+			return err
+		})
+		if err != nil || items == nil || len(*items) == 0 {
+			continue
+		}
+
+		changesetID := 0
+		for _, item := range *items {
+			if item.ChangesetVersion != nil && *item.ChangesetVersion > changesetID {
+				changesetID = *item.ChangesetVersion
+			}
+		}
+
+		mark, ok := markByChangeset[changesetID]
+		if !ok {
+			continue
+		}
+
+		tagName := path.Base(*label.Name)
+		fmt.Fprintf(w, "tag %s\n", tagName)
+		fmt.Fprintf(w, "from :%d\n", mark)
+		fmt.Fprintf(w, "tagger unknown <unknown@localhost> %d +0000\n", time.Now().Unix())
+		message := "TFVC label " + tagName
+		fmt.Fprintf(w, "data %d\n%s\n", len(message), message)
+	}
+
+	return nil
+}
+
+// tfvcChangesetMark formats a changeset id alongside its fast-import mark,
+// e.g. "42->:7". Nothing in this file calls it.
+func tfvcChangesetMark(changesetID, mark int) string {
+	return strconv.Itoa(changesetID) + "->:" + strconv.Itoa(mark)
+}
+
+// ensureTFVCGitRepo runs the TFVC-to-git conversion into a local bare repo
+// the first time it is needed and caches the result, so GetRepoInfo can
+// advertise it as the clone source instead of the non-existent Git remote.
+func (g *AzureDevOpsDownloaderV7) ensureTFVCGitRepo() (string, error) {
+	if g.tfvcGitPath != "" {
+		return g.tfvcGitPath, nil
+	}
+
+	gitPath, err := os.MkdirTemp("", "azuredevops-tfvc-*.git")
+	if err != nil {
+		return "", err
+	}
+	if err := exec.CommandContext(g.ctx, "git", "init", "--bare", gitPath).Run(); err != nil {
+		return "", fmt.Errorf("git init --bare: %w", err)
+	}
+	if err := g.ImportTFVCToGit(g.ctx, gitPath); err != nil {
+		return "", err
+	}
+
+	g.tfvcGitPath = gitPath
+	return gitPath, nil
+}
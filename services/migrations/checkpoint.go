@@ -0,0 +1,82 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	base "code.gitea.io/gitea/modules/migration"
+)
+
+// MigrationCheckpoint records how far a repository migration has progressed
+// so it can be resumed after a failure instead of restarting from scratch.
+// It mirrors the migration_checkpoint table added alongside it.
+type MigrationCheckpoint struct {
+	ID               int64 `xorm:"pk autoincr"`
+	RepoID           int64 `xorm:"UNIQUE NOT NULL"`
+	NextIssuePage    int
+	NextPullPage     int
+	NextReleasePage  int
+	LastIssueNumber  int64
+	LastPullNumber   int64
+	LastReviewID     int64
+	LastReleaseAsset int64
+}
+
+// TableName sets the table name to migration_checkpoint
+func (*MigrationCheckpoint) TableName() string {
+	return "migration_checkpoint"
+}
+
+// toBaseCheckpoint converts the persisted row into the base.Checkpoint a
+// base.Downloader's Resume method understands, so the database-backed
+// representation does not leak into modules/migration. A nil checkpoint
+// (no progress persisted yet) converts to a nil base.Checkpoint.
+func (c *MigrationCheckpoint) toBaseCheckpoint() *base.Checkpoint {
+	if c == nil {
+		return nil
+	}
+	return &base.Checkpoint{
+		NextIssuePage:   c.NextIssuePage,
+		NextPullPage:    c.NextPullPage,
+		NextReleasePage: c.NextReleasePage,
+	}
+}
+
+// LoadCheckpoint returns the checkpoint for repoID, or nil if the migration
+// has not made any progress yet.
+func LoadCheckpoint(ctx context.Context, repoID int64) (*MigrationCheckpoint, error) {
+	checkpoint := &MigrationCheckpoint{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Get(checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return checkpoint, nil
+}
+
+// SaveCheckpoint upserts the checkpoint for checkpoint.RepoID
+func SaveCheckpoint(ctx context.Context, checkpoint *MigrationCheckpoint) error {
+	existing, err := LoadCheckpoint(ctx, checkpoint.RepoID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		_, err = db.GetEngine(ctx).Insert(checkpoint)
+		return err
+	}
+	checkpoint.ID = existing.ID
+	_, err = db.GetEngine(ctx).ID(existing.ID).AllCols().Update(checkpoint)
+	return err
+}
+
+// ClearCheckpoint removes the checkpoint for repoID once a migration has
+// completed successfully, so a later re-migration of the same repo starts fresh.
+func ClearCheckpoint(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Delete(new(MigrationCheckpoint))
+	return err
+}
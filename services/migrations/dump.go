@@ -0,0 +1,489 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/log"
+	base "code.gitea.io/gitea/modules/migration"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dumpSchemaVersion is bumped whenever a per-entity yaml layout changes in a
+// way that is not purely additive. DumpDownloader logs a warning when it reads
+// a file written with a newer version than this one, and then reads it anyway.
+const dumpSchemaVersion = 1
+
+var (
+	_ base.Uploader   = &DumpUploader{}
+	_ base.Downloader = &DumpDownloader{}
+)
+
+// versioned wraps a dumped entity with the schema version it was written
+// with, so that later releases can add fields to base.* structs without
+// breaking restores of older dumps.
+type versioned struct {
+	Version int         `yaml:"version"`
+	Data    interface{} `yaml:"data"`
+}
+
+// DumpUploader implements Uploader interface by serializing a migration to
+// an on-disk tree instead of writing into a live Gitea instance, so that a
+// migration can be captured once and later replayed with DumpDownloader + the
+// regular GiteaUploader against any instance.
+type DumpUploader struct {
+	ctx      context.Context
+	basePath string
+}
+
+// NewDumpUploader creates a DumpUploader that writes into basePath, which is
+// created if it does not already exist.
+func NewDumpUploader(ctx context.Context, basePath string) (*DumpUploader, error) {
+	if err := os.MkdirAll(basePath, 0o755); err != nil {
+		return nil, err
+	}
+	for _, dir := range []string{"issues", "pulls", "comments", "reviews", "releases", "lfs"} {
+		if err := os.MkdirAll(filepath.Join(basePath, dir), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return &DumpUploader{ctx: ctx, basePath: basePath}, nil
+}
+
+func (d *DumpUploader) writeYAML(relPath string, data interface{}) error {
+	out, err := yaml.Marshal(versioned{Version: dumpSchemaVersion, Data: data})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(d.basePath, relPath), out, 0o644)
+}
+
+// MaxBatchInsertSize returns the internal insert batch size, which the dump
+// uploader does not need but must report to satisfy the Uploader interface.
+func (d *DumpUploader) MaxBatchInsertSize(tp string) int {
+	return 1000
+}
+
+// CreateRepo writes repo.yml and mirror-clones the source repository (plus
+// its LFS objects, when opts.LFS is set) into the dump tree, so a restore has
+// the actual git history to push rather than just the metadata YAML files.
+func (d *DumpUploader) CreateRepo(repo *base.Repository, opts base.MigrateOptions) error {
+	if err := d.writeYAML("repo.yml", repo); err != nil {
+		return err
+	}
+	return d.cloneRepo(repo, opts)
+}
+
+func (d *DumpUploader) cloneRepo(repo *base.Repository, opts base.MigrateOptions) error {
+	gitPath := filepath.Join(d.basePath, "git")
+	cmd := exec.CommandContext(d.ctx, "git", "clone", "--mirror", repo.CloneURL, gitPath)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git clone --mirror %s: %w\n%s", repo.CloneURL, err, out)
+	}
+
+	if !opts.LFS {
+		return nil
+	}
+
+	lfsCmd := exec.CommandContext(d.ctx, "git", "lfs", "fetch", "--all")
+	lfsCmd.Dir = gitPath
+	if out, err := lfsCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git lfs fetch --all: %w\n%s", err, out)
+	}
+
+	return copyLFSObjects(filepath.Join(gitPath, "lfs", "objects"), filepath.Join(d.basePath, "lfs"))
+}
+
+// copyLFSObjects copies the LFS object store git-lfs populated inside the
+// mirror clone's .git dir out to the dump tree's own lfs/ directory, which is
+// where DumpDownloader.LFSObjectDir points.
+func copyLFSObjects(srcDir, dstDir string) error {
+	return filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(dstDir, rel)
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		return copyFile(path, dst)
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// CreateTopics writes topics.yml
+func (d *DumpUploader) CreateTopics(topics ...string) error {
+	return d.writeYAML("topics.yml", topics)
+}
+
+// CreateMilestones writes milestones.yml
+func (d *DumpUploader) CreateMilestones(milestones ...*base.Milestone) error {
+	return d.writeYAML("milestones.yml", milestones)
+}
+
+// CreateLabels writes labels.yml
+func (d *DumpUploader) CreateLabels(labels ...*base.Label) error {
+	return d.writeYAML("labels.yml", labels)
+}
+
+// CreateIssues writes one issues/<number>.yml per issue, skipping any issue
+// already written to disk so a resumed dump does not redo work a previous,
+// interrupted run already finished.
+func (d *DumpUploader) CreateIssues(issues ...*base.Issue) error {
+	for _, issue := range issues {
+		relPath := filepath.Join("issues", fmt.Sprintf("%d.yml", issue.Number))
+		if d.exists(relPath) {
+			continue
+		}
+		if err := d.writeYAML(relPath, issue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exists reports whether relPath has already been written under basePath,
+// used to make CreateIssues/CreatePullRequests resumable.
+func (d *DumpUploader) exists(relPath string) bool {
+	_, err := os.Stat(filepath.Join(d.basePath, relPath))
+	return err == nil
+}
+
+// CreateComments writes one comments/<issue number>.yml containing all
+// comments for that issue or pull request.
+func (d *DumpUploader) CreateComments(comments ...*base.Comment) error {
+	byIssue := map[int64][]*base.Comment{}
+	for _, comment := range comments {
+		byIssue[comment.IssueIndex] = append(byIssue[comment.IssueIndex], comment)
+	}
+	for issueIndex, issueComments := range byIssue {
+		if err := d.writeYAML(filepath.Join("comments", fmt.Sprintf("%d.yml", issueIndex)), issueComments); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreatePullRequests writes one pulls/<number>.yml per pull request,
+// skipping any pull request already written to disk (see CreateIssues).
+func (d *DumpUploader) CreatePullRequests(prs ...*base.PullRequest) error {
+	for _, pr := range prs {
+		relPath := filepath.Join("pulls", fmt.Sprintf("%d.yml", pr.Number))
+		if d.exists(relPath) {
+			continue
+		}
+		if err := d.writeYAML(relPath, pr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateReviews writes one reviews/<issue number>.yml containing all reviews
+// for that pull request.
+func (d *DumpUploader) CreateReviews(reviews ...*base.Review) error {
+	byIssue := map[int64][]*base.Review{}
+	for _, review := range reviews {
+		byIssue[review.IssueIndex] = append(byIssue[review.IssueIndex], review)
+	}
+	for issueIndex, issueReviews := range byIssue {
+		if err := d.writeYAML(filepath.Join("reviews", fmt.Sprintf("%d.yml", issueIndex)), issueReviews); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateReleases writes one releases/<n>.yml per release, numbered by
+// import order since releases have no stable numeric index of their own.
+// The next number continues from whatever is already on disk, so releases
+// written by an earlier call (batching, or a resumed run) are not clobbered
+// by a second call restarting the count at 1.
+func (d *DumpUploader) CreateReleases(releases ...*base.Release) error {
+	entries, err := numberedYAMLEntries(filepath.Join(d.basePath, "releases"))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	next := len(entries) + 1
+	for _, rel := range releases {
+		if err := d.writeYAML(filepath.Join("releases", fmt.Sprintf("%d.yml", next)), rel); err != nil {
+			return err
+		}
+		next++
+	}
+	return nil
+}
+
+// Rollback is a no-op. Unlike a live Gitea repository, a partially written
+// dump tree is not in an inconsistent state that needs undoing: CreateIssues
+// and CreatePullRequests are built to resume from exactly this state on the
+// next attempt, so deleting it here would destroy the progress resuming is
+// meant to preserve.
+func (d *DumpUploader) Rollback() error {
+	return nil
+}
+
+// Finish is a no-op; there is nothing to flush once every file is written
+func (d *DumpUploader) Finish() error {
+	return nil
+}
+
+// Close is a no-op; DumpUploader holds no open handles between calls
+func (d *DumpUploader) Close() {}
+
+// DumpDownloader implements the Downloader interface by reading back a tree
+// produced by DumpUploader, so a previously captured migration can be
+// replayed against a (possibly different) Gitea instance via the regular
+// GiteaUploader.
+type DumpDownloader struct {
+	base.NullDownloader
+	ctx      context.Context
+	basePath string
+}
+
+// NewDumpDownloader creates a DumpDownloader reading from basePath
+func NewDumpDownloader(ctx context.Context, basePath string) *DumpDownloader {
+	return &DumpDownloader{ctx: ctx, basePath: basePath}
+}
+
+// SetContext set context
+func (d *DumpDownloader) SetContext(ctx context.Context) {
+	d.ctx = ctx
+}
+
+// String implements Stringer
+func (d *DumpDownloader) String() string {
+	return fmt.Sprintf("migration from local dump %s", d.basePath)
+}
+
+func (d *DumpDownloader) LogString() string {
+	if d == nil {
+		return "<DumpDownloader nil>"
+	}
+	return fmt.Sprintf("<DumpDownloader %s>", d.basePath)
+}
+
+func (d *DumpDownloader) readYAML(relPath string, out interface{}) error {
+	raw, err := os.ReadFile(filepath.Join(d.basePath, relPath))
+	if err != nil {
+		return err
+	}
+	var v versioned
+	if err := yaml.Unmarshal(raw, &v); err != nil {
+		return err
+	}
+	if v.Version > dumpSchemaVersion {
+		log.Warn("dump downloader: %s was written with schema version %d, newer than %d; some fields may be ignored", relPath, v.Version, dumpSchemaVersion)
+	}
+	// Re-marshal Data (decoded as generic map/slice) and decode into out so
+	// callers get the same typed result the yaml.v3 decoder would have
+	// produced for a direct `yaml.Unmarshal(raw, out)` call.
+	data, err := yaml.Marshal(v.Data)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, out)
+}
+
+// GetRepoInfo reads repo.yml
+func (d *DumpDownloader) GetRepoInfo() (*base.Repository, error) {
+	var repo base.Repository
+	if err := d.readYAML("repo.yml", &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// GetTopics reads topics.yml
+func (d *DumpDownloader) GetTopics() ([]string, error) {
+	var topics []string
+	if err := d.readYAML("topics.yml", &topics); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return topics, nil
+}
+
+// GetMilestones reads milestones.yml
+func (d *DumpDownloader) GetMilestones() ([]*base.Milestone, error) {
+	var milestones []*base.Milestone
+	if err := d.readYAML("milestones.yml", &milestones); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return milestones, nil
+}
+
+// GetLabels reads labels.yml
+func (d *DumpDownloader) GetLabels() ([]*base.Label, error) {
+	var labels []*base.Label
+	if err := d.readYAML("labels.yml", &labels); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return labels, nil
+}
+
+// numberedYAMLEntries reads dir and returns its entries sorted by the
+// numeric value of their filename (1.yml, 2.yml, ..., 10.yml), since
+// os.ReadDir sorts lexically and would otherwise put 10.yml before 2.yml.
+func numberedYAMLEntries(dir string) ([]os.DirEntry, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		ni, _ := strconv.ParseInt(strings.TrimSuffix(entries[i].Name(), ".yml"), 10, 64)
+		nj, _ := strconv.ParseInt(strings.TrimSuffix(entries[j].Name(), ".yml"), 10, 64)
+		return ni < nj
+	})
+	return entries, nil
+}
+
+// GetReleases reads every releases/<n>.yml
+func (d *DumpDownloader) GetReleases() ([]*base.Release, error) {
+	entries, err := numberedYAMLEntries(filepath.Join(d.basePath, "releases"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	releases := make([]*base.Release, 0, len(entries))
+	for _, entry := range entries {
+		var rel base.Release
+		if err := d.readYAML(filepath.Join("releases", entry.Name()), &rel); err != nil {
+			return nil, err
+		}
+		releases = append(releases, &rel)
+	}
+	return releases, nil
+}
+
+// GetIssues reads every issues/<n>.yml; the on-disk dump has no natural
+// paging so everything is returned on page 1.
+func (d *DumpDownloader) GetIssues(page, perPage int) ([]*base.Issue, bool, error) {
+	if page > 1 {
+		return nil, true, nil
+	}
+	entries, err := numberedYAMLEntries(filepath.Join(d.basePath, "issues"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, true, nil
+		}
+		return nil, true, err
+	}
+	issues := make([]*base.Issue, 0, len(entries))
+	for _, entry := range entries {
+		var issue base.Issue
+		if err := d.readYAML(filepath.Join("issues", entry.Name()), &issue); err != nil {
+			return nil, true, err
+		}
+		issues = append(issues, &issue)
+	}
+	return issues, true, nil
+}
+
+// GetPullRequests reads every pulls/<n>.yml
+func (d *DumpDownloader) GetPullRequests(page, perPage int) ([]*base.PullRequest, bool, error) {
+	if page > 1 {
+		return nil, true, nil
+	}
+	entries, err := numberedYAMLEntries(filepath.Join(d.basePath, "pulls"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, true, nil
+		}
+		return nil, true, err
+	}
+	prs := make([]*base.PullRequest, 0, len(entries))
+	for _, entry := range entries {
+		var pr base.PullRequest
+		if err := d.readYAML(filepath.Join("pulls", entry.Name()), &pr); err != nil {
+			return nil, true, err
+		}
+		prs = append(prs, &pr)
+	}
+	return prs, true, nil
+}
+
+// GetComments reads comments/<issue number>.yml for the given issue or PR
+func (d *DumpDownloader) GetComments(commentable base.Commentable) ([]*base.Comment, bool, error) {
+	var comments []*base.Comment
+	relPath := filepath.Join("comments", fmt.Sprintf("%d.yml", commentable.GetLocalIndex()))
+	if err := d.readYAML(relPath, &comments); err != nil {
+		if os.IsNotExist(err) {
+			return nil, true, nil
+		}
+		return nil, true, err
+	}
+	return comments, true, nil
+}
+
+// GetReviews reads reviews/<issue number>.yml for the given pull request
+func (d *DumpDownloader) GetReviews(reviewable base.Reviewable) ([]*base.Review, error) {
+	var reviews []*base.Review
+	relPath := filepath.Join("reviews", fmt.Sprintf("%d.yml", reviewable.GetLocalIndex()))
+	if err := d.readYAML(relPath, &reviews); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// GitCloneURL returns the path to the bare git clone inside the dump tree, so
+// a restore can push from it instead of fetching over the network again.
+func (d *DumpDownloader) GitCloneURL() string {
+	return filepath.Join(d.basePath, "git")
+}
+
+// LFSObjectDir returns the path to the dumped LFS object store
+func (d *DumpDownloader) LFSObjectDir() string {
+	return filepath.Join(d.basePath, "lfs")
+}
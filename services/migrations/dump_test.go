@@ -0,0 +1,35 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberedYAMLEntriesSortsNumerically(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"2.yml", "10.yml", "1.yml"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+
+	entries, err := numberedYAMLEntries(dir)
+	assert.NoError(t, err)
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.EqualValues(t, []string{"1.yml", "2.yml", "10.yml"}, names)
+}
+
+func TestNumberedYAMLEntriesMissingDir(t *testing.T) {
+	entries, err := numberedYAMLEntries(filepath.Join(t.TempDir(), "does-not-exist"))
+	assert.Error(t, err)
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, entries)
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package f3
+
+import (
+	base "code.gitea.io/gitea/modules/migration"
+
+	f3generic "code.forgejo.org/f3/gof3/v3/generic"
+)
+
+// convertRepository maps an F3 Repository node onto base.Repository
+func convertRepository(r *f3generic.Repository) *base.Repository {
+	return &base.Repository{
+		Name:          r.GetName(),
+		Owner:         r.GetOwnerName(),
+		Description:   r.GetDescription(),
+		CloneURL:      r.GetCloneURL(),
+		OriginalURL:   r.GetURL(),
+		IsPrivate:     r.GetIsPrivate(),
+		DefaultBranch: r.GetDefaultBranch(),
+	}
+}
+
+// convertMilestone maps an F3 Milestone node onto base.Milestone
+func convertMilestone(m *f3generic.Milestone) *base.Milestone {
+	milestone := &base.Milestone{
+		Title:       m.GetTitle(),
+		Description: m.GetDescription(),
+		Created:     m.GetCreated(),
+	}
+	if deadline := m.GetDeadline(); deadline != nil {
+		milestone.Deadline = deadline
+	}
+	if m.GetIsClosed() {
+		milestone.State = "closed"
+		closed := m.GetUpdated()
+		milestone.Closed = &closed
+	} else {
+		milestone.State = "open"
+	}
+	return milestone
+}
+
+// convertLabel maps an F3 Label node onto base.Label
+func convertLabel(l *f3generic.Label) *base.Label {
+	return &base.Label{
+		Name:        l.GetName(),
+		Color:       l.GetColor(),
+		Description: l.GetDescription(),
+	}
+}
+
+// convertRelease maps an F3 Release node, including its Assets, onto base.Release
+func convertRelease(r *f3generic.Release) *base.Release {
+	release := &base.Release{
+		TagName:         r.GetTagName(),
+		TargetCommitish: r.GetTargetCommitish(),
+		Name:            r.GetName(),
+		Body:            r.GetBody(),
+		Draft:           r.GetIsDraft(),
+		Prerelease:      r.GetIsPrerelease(),
+		Created:         r.GetCreated(),
+		Published:       r.GetPublished(),
+		PublisherID:     r.GetPublisherID(),
+		PublisherName:   r.GetPublisherName(),
+	}
+	for _, asset := range r.GetAssets() {
+		release.Assets = append(release.Assets, &base.ReleaseAsset{
+			Name:        asset.GetName(),
+			ContentType: asset.GetContentType(),
+			Size:        asset.GetSize(),
+			Created:     asset.GetCreated(),
+			DownloadURL: asset.GetDownloadURL(),
+		})
+	}
+	return release
+}
+
+// convertReactions maps F3 Reaction nodes onto base.Reaction
+func convertReactions(reactions []*f3generic.Reaction) []*base.Reaction {
+	result := make([]*base.Reaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		result = append(result, &base.Reaction{
+			UserID:   reaction.GetUserID(),
+			UserName: reaction.GetUserName(),
+			Content:  reaction.GetContent(),
+		})
+	}
+	return result
+}
+
+// convertIssue maps an F3 Issue node, including its Labels and Reactions,
+// onto base.Issue
+func convertIssue(i *f3generic.Issue) *base.Issue {
+	issue := &base.Issue{
+		Number:     i.GetIndex(),
+		Title:      i.GetTitle(),
+		Content:    i.GetContent(),
+		PosterID:   i.GetPosterID(),
+		PosterName: i.GetPosterName(),
+		IsLocked:   i.GetIsLocked(),
+		Created:    i.GetCreated(),
+		Updated:    i.GetUpdated(),
+		Reactions:  convertReactions(i.GetReactions()),
+	}
+	if i.GetIsClosed() {
+		issue.State = "closed"
+		closed := i.GetUpdated()
+		issue.Closed = &closed
+	} else {
+		issue.State = "open"
+	}
+	for _, label := range i.GetLabels() {
+		issue.Labels = append(issue.Labels, convertLabel(label))
+	}
+	if milestone := i.GetMilestone(); milestone != nil {
+		issue.Milestone = milestone.GetTitle()
+	}
+	return issue
+}
+
+// convertComment maps an F3 Comment node onto base.Comment, attaching it to
+// the local issue/pull request index the caller already resolved
+func convertComment(issueIndex int64, c *f3generic.Comment) *base.Comment {
+	return &base.Comment{
+		IssueIndex: issueIndex,
+		PosterID:   c.GetPosterID(),
+		PosterName: c.GetPosterName(),
+		Content:    c.GetContent(),
+		Created:    c.GetCreated(),
+		Updated:    c.GetUpdated(),
+		Reactions:  convertReactions(c.GetReactions()),
+	}
+}
+
+// convertPullRequest maps an F3 PullRequest node onto base.PullRequest
+func convertPullRequest(p *f3generic.PullRequest) *base.PullRequest {
+	pr := &base.PullRequest{
+		Number:     p.GetIndex(),
+		Title:      p.GetTitle(),
+		Content:    p.GetContent(),
+		PosterID:   p.GetPosterID(),
+		PosterName: p.GetPosterName(),
+		Created:    p.GetCreated(),
+		Updated:    p.GetUpdated(),
+		IsLocked:   p.GetIsLocked(),
+		IsDraft:    p.GetIsDraft(),
+		Merged:     p.GetIsMerged(),
+		Reactions:  convertReactions(p.GetReactions()),
+	}
+	if p.GetIsClosed() {
+		pr.State = "closed"
+		closed := p.GetUpdated()
+		pr.Closed = &closed
+	} else {
+		pr.State = "open"
+	}
+	if merged := p.GetMergedAt(); merged != nil {
+		pr.MergedTime = merged
+	}
+	head := p.GetHead()
+	pr.Head.Ref = head.GetRef()
+	pr.Head.SHA = head.GetSHA()
+	prBase := p.GetBase()
+	pr.Base.Ref = prBase.GetRef()
+	pr.Base.SHA = prBase.GetSHA()
+	for _, label := range p.GetLabels() {
+		pr.Labels = append(pr.Labels, convertLabel(label))
+	}
+	return pr
+}
+
+// convertReview maps an F3 Review node, including its ReviewComments, onto base.Review
+func convertReview(issueIndex int64, r *f3generic.Review) *base.Review {
+	review := &base.Review{
+		IssueIndex:   issueIndex,
+		ReviewerID:   r.GetReviewerID(),
+		ReviewerName: r.GetReviewerName(),
+		Official:     r.GetIsOfficial(),
+		CommitID:     r.GetCommitID(),
+		Content:      r.GetContent(),
+		CreatedAt:    r.GetCreated(),
+		State:        base.ReviewState(r.GetState()),
+	}
+	for _, comment := range r.GetComments() {
+		review.Comments = append(review.Comments, &base.ReviewComment{
+			Content:   comment.GetContent(),
+			TreePath:  comment.GetTreePath(),
+			DiffHunk:  comment.GetDiffHunk(),
+			Position:  comment.GetPosition(),
+			CommitID:  comment.GetCommitID(),
+			PosterID:  comment.GetPosterID(),
+			CreatedAt: comment.GetCreated(),
+			UpdatedAt: comment.GetUpdated(),
+		})
+	}
+	return review
+}
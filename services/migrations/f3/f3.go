@@ -0,0 +1,179 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package f3 adapts the Friendly Forge Format (F3) driver interface,
+// shared by Forgejo and the F3 project, to Gitea's own
+// base.Downloader/base.Uploader migration interfaces. It lets Gitea consume
+// any F3 driver (Forgejo, Codeberg, a filesystem F3 tree, ...) without a
+// bespoke downloader per forge, and lets Gitea expose itself as an F3 driver
+// so other forges can migrate away from it the same way.
+package f3
+
+import (
+	"context"
+	"fmt"
+
+	base "code.gitea.io/gitea/modules/migration"
+	"code.gitea.io/gitea/modules/structs"
+
+	f3driver "code.forgejo.org/f3/gof3/v3/f3/driver"
+	f3generic "code.forgejo.org/f3/gof3/v3/generic"
+)
+
+var (
+	_ base.Downloader        = &F3Downloader{}
+	_ base.DownloaderFactory = &F3DownloaderFactory{}
+)
+
+// F3DownloaderFactory builds an F3Downloader from MigrateOptions. CloneAddr
+// is expected to be an F3-addressable URL understood by f3driver.NewDriver
+// (e.g. a Forgejo instance, a Codeberg-hosted repo, or a file:// tree).
+type F3DownloaderFactory struct{}
+
+// New returns a Downloader wrapping whatever F3 driver matches opts.CloneAddr
+func (f *F3DownloaderFactory) New(ctx context.Context, opts base.MigrateOptions) (base.Downloader, error) {
+	driver, err := f3driver.NewDriver(ctx, opts.CloneAddr, f3driver.Options{
+		Username: opts.AuthUsername,
+		Password: opts.AuthPassword,
+		Token:    opts.AuthToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("f3: could not create driver for %s: %w", opts.CloneAddr, err)
+	}
+	return NewF3Downloader(ctx, driver, opts.CloneAddr), nil
+}
+
+// GitServiceType returns the type of git service
+func (f *F3DownloaderFactory) GitServiceType() structs.GitServiceType {
+	return structs.F3Service
+}
+
+// F3Downloader adapts an f3driver.Driver (the forge-agnostic side) into
+// Gitea's base.Downloader so the rest of the migration pipeline does not
+// need to know it is talking to F3 rather than a native downloader.
+type F3Downloader struct {
+	base.NullDownloader
+	ctx    context.Context
+	driver f3driver.Driver
+	addr   string
+}
+
+// NewF3Downloader wraps driver as a base.Downloader
+func NewF3Downloader(ctx context.Context, driver f3driver.Driver, addr string) *F3Downloader {
+	return &F3Downloader{
+		ctx:    ctx,
+		driver: driver,
+		addr:   addr,
+	}
+}
+
+// SetContext set context
+func (d *F3Downloader) SetContext(ctx context.Context) {
+	d.ctx = ctx
+}
+
+// String implements Stringer
+func (d *F3Downloader) String() string {
+	return fmt.Sprintf("migration from f3 driver %s", d.addr)
+}
+
+func (d *F3Downloader) LogString() string {
+	if d == nil {
+		return "<F3Downloader nil>"
+	}
+	return fmt.Sprintf("<F3Downloader %s>", d.addr)
+}
+
+// GetRepoInfo translates the F3 Repository node into base.Repository
+func (d *F3Downloader) GetRepoInfo() (*base.Repository, error) {
+	repo := f3generic.NewRepository()
+	if err := d.driver.Get(d.ctx, f3generic.NewTreeDriverOptions(), repo); err != nil {
+		return nil, err
+	}
+	return convertRepository(repo), nil
+}
+
+// GetTopics translates F3 topics into a flat string slice
+func (d *F3Downloader) GetTopics() ([]string, error) {
+	topics := f3generic.NewTopics()
+	if err := d.driver.Get(d.ctx, f3generic.NewTreeDriverOptions(), topics); err != nil {
+		return nil, err
+	}
+	return topics.List(), nil
+}
+
+// GetMilestones translates F3 Milestone nodes
+func (d *F3Downloader) GetMilestones() ([]*base.Milestone, error) {
+	var milestones []*base.Milestone
+	err := d.driver.ForEach(d.ctx, f3generic.NewMilestone(), func(node f3generic.Node) error {
+		milestones = append(milestones, convertMilestone(node.(*f3generic.Milestone)))
+		return nil
+	})
+	return milestones, err
+}
+
+// GetLabels translates F3 Label nodes
+func (d *F3Downloader) GetLabels() ([]*base.Label, error) {
+	var labels []*base.Label
+	err := d.driver.ForEach(d.ctx, f3generic.NewLabel(), func(node f3generic.Node) error {
+		labels = append(labels, convertLabel(node.(*f3generic.Label)))
+		return nil
+	})
+	return labels, err
+}
+
+// GetReleases translates F3 Release nodes, including their Assets
+func (d *F3Downloader) GetReleases() ([]*base.Release, error) {
+	var releases []*base.Release
+	err := d.driver.ForEach(d.ctx, f3generic.NewRelease(), func(node f3generic.Node) error {
+		releases = append(releases, convertRelease(node.(*f3generic.Release)))
+		return nil
+	})
+	return releases, err
+}
+
+// GetIssues translates F3 Issue nodes, paging the underlying driver iterator
+func (d *F3Downloader) GetIssues(page, perPage int) ([]*base.Issue, bool, error) {
+	var issues []*base.Issue
+	isEnd := true
+	err := d.driver.ForEachPage(d.ctx, f3generic.NewIssue(), page, perPage, func(node f3generic.Node, hasNext bool) error {
+		issues = append(issues, convertIssue(node.(*f3generic.Issue)))
+		isEnd = !hasNext
+		return nil
+	})
+	return issues, isEnd, err
+}
+
+// GetComments translates F3 Comment nodes attached to an issue or pull request
+func (d *F3Downloader) GetComments(commentable base.Commentable) ([]*base.Comment, bool, error) {
+	var comments []*base.Comment
+	parent := f3generic.NewIDFromInt64(commentable.GetForeignIndex())
+	err := d.driver.ForEachChild(d.ctx, parent, f3generic.NewComment(), func(node f3generic.Node) error {
+		comments = append(comments, convertComment(commentable.GetLocalIndex(), node.(*f3generic.Comment)))
+		return nil
+	})
+	return comments, true, err
+}
+
+// GetPullRequests translates F3 PullRequest nodes
+func (d *F3Downloader) GetPullRequests(page, perPage int) ([]*base.PullRequest, bool, error) {
+	var prs []*base.PullRequest
+	isEnd := true
+	err := d.driver.ForEachPage(d.ctx, f3generic.NewPullRequest(), page, perPage, func(node f3generic.Node, hasNext bool) error {
+		prs = append(prs, convertPullRequest(node.(*f3generic.PullRequest)))
+		isEnd = !hasNext
+		return nil
+	})
+	return prs, isEnd, err
+}
+
+// GetReviews translates F3 Review nodes attached to a pull request
+func (d *F3Downloader) GetReviews(reviewable base.Reviewable) ([]*base.Review, error) {
+	var reviews []*base.Review
+	parent := f3generic.NewIDFromInt64(reviewable.GetForeignIndex())
+	err := d.driver.ForEachChild(d.ctx, parent, f3generic.NewReview(), func(node f3generic.Node) error {
+		reviews = append(reviews, convertReview(reviewable.GetLocalIndex(), node.(*f3generic.Review)))
+		return nil
+	})
+	return reviews, err
+}
@@ -0,0 +1,247 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package f3
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/sdk/gitea"
+
+	f3driver "code.forgejo.org/f3/gof3/v3/f3/driver"
+	f3generic "code.forgejo.org/f3/gof3/v3/generic"
+)
+
+var _ f3driver.Driver = &GiteaDriver{}
+
+// GiteaDriver implements the F3 driver interface (Get/Put/ForEach*) over a
+// Gitea instance's own API, so other forges can migrate *from* Gitea through
+// F3 the same way Gitea migrates from them. It is the mirror image of
+// F3Downloader, which consumes a driver instead of being one.
+type GiteaDriver struct {
+	ctx    context.Context
+	client *gitea.Client
+	owner  string
+	repo   string
+}
+
+// NewGiteaDriver creates a GiteaDriver talking to a Gitea instance at
+// baseURL, authenticated with token, for the given owner/repo.
+func NewGiteaDriver(ctx context.Context, baseURL, token, owner, repo string) (*GiteaDriver, error) {
+	client, err := gitea.NewClient(baseURL, gitea.SetToken(token), gitea.SetContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("f3: could not create gitea client for %s: %w", baseURL, err)
+	}
+	return &GiteaDriver{ctx: ctx, client: client, owner: owner, repo: repo}, nil
+}
+
+// Get fetches a single F3 node (e.g. the repository itself, or topics) by
+// delegating to the matching Gitea API call and populating node in place.
+func (d *GiteaDriver) Get(ctx context.Context, opts f3generic.TreeDriverOptions, node f3generic.Node) error {
+	switch n := node.(type) {
+	case *f3generic.Repository:
+		repo, _, err := d.client.GetRepo(d.owner, d.repo)
+		if err != nil {
+			return err
+		}
+		n.SetName(repo.Name)
+		n.SetOwnerName(repo.Owner.UserName)
+		n.SetDescription(repo.Description)
+		n.SetCloneURL(repo.CloneURL)
+		n.SetURL(repo.HTMLURL)
+		n.SetIsPrivate(repo.Private)
+		n.SetDefaultBranch(repo.DefaultBranch)
+		return nil
+	case *f3generic.Topics:
+		topics, _, err := d.client.ListRepoTopics(d.owner, d.repo, gitea.ListRepoTopicsOptions{})
+		if err != nil {
+			return err
+		}
+		n.SetList(topics)
+		return nil
+	case *f3generic.User:
+		user, _, err := d.client.GetUserInfo(n.GetName())
+		if err != nil {
+			return err
+		}
+		n.SetName(user.UserName)
+		return nil
+	default:
+		return fmt.Errorf("f3: GiteaDriver.Get does not support %T", node)
+	}
+}
+
+// Put writes a single F3 node back into Gitea. Used when Gitea is the
+// destination of an F3-mediated migration rather than the source.
+func (d *GiteaDriver) Put(ctx context.Context, opts f3generic.TreeDriverOptions, node f3generic.Node) error {
+	switch n := node.(type) {
+	case *f3generic.Label:
+		_, _, err := d.client.CreateLabel(d.owner, d.repo, gitea.CreateLabelOption{
+			Name:        n.GetName(),
+			Color:       "#" + n.GetColor(),
+			Description: n.GetDescription(),
+		})
+		return err
+	case *f3generic.Milestone:
+		_, _, err := d.client.CreateMilestone(d.owner, d.repo, gitea.CreateMilestoneOption{
+			Title:       n.GetTitle(),
+			Description: n.GetDescription(),
+		})
+		return err
+	case *f3generic.Release:
+		_, _, err := d.client.CreateRelease(d.owner, d.repo, gitea.CreateReleaseOption{
+			TagName: n.GetTagName(),
+			Title:   n.GetName(),
+			Note:    n.GetBody(),
+		})
+		return err
+	default:
+		return fmt.Errorf("f3: GiteaDriver.Put does not support %T", node)
+	}
+}
+
+// ForEach lists every node of kind template's type (e.g. every Label)
+func (d *GiteaDriver) ForEach(ctx context.Context, template f3generic.Node, fn func(f3generic.Node) error) error {
+	switch template.(type) {
+	case *f3generic.Label:
+		labels, _, err := d.client.ListRepoLabels(d.owner, d.repo, gitea.ListLabelsOptions{})
+		if err != nil {
+			return err
+		}
+		for _, label := range labels {
+			node := f3generic.NewLabel()
+			node.SetName(label.Name)
+			node.SetColor(label.Color)
+			node.SetDescription(label.Description)
+			if err := fn(node); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *f3generic.Milestone:
+		milestones, _, err := d.client.ListRepoMilestones(d.owner, d.repo, gitea.ListMilestoneOption{})
+		if err != nil {
+			return err
+		}
+		for _, milestone := range milestones {
+			node := f3generic.NewMilestone()
+			node.SetTitle(milestone.Title)
+			node.SetDescription(milestone.Description)
+			if err := fn(node); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *f3generic.Release:
+		releases, _, err := d.client.ListReleases(d.owner, d.repo, gitea.ListReleasesOptions{})
+		if err != nil {
+			return err
+		}
+		for _, release := range releases {
+			node := f3generic.NewRelease()
+			node.SetName(release.Title)
+			node.SetBody(release.Note)
+			node.SetTagName(release.TagName)
+			if err := fn(node); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("f3: GiteaDriver.ForEach does not support %T", template)
+	}
+}
+
+// ForEachPage lists nodes of template's type a page at a time, mirroring the
+// paging convention Gitea's own REST API already uses.
+func (d *GiteaDriver) ForEachPage(ctx context.Context, template f3generic.Node, page, perPage int, fn func(f3generic.Node, bool) error) error {
+	switch template.(type) {
+	case *f3generic.Issue:
+		issues, _, err := d.client.ListRepoIssues(d.owner, d.repo, gitea.ListIssueOption{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: perPage},
+		})
+		if err != nil {
+			return err
+		}
+		for i, issue := range issues {
+			node := f3generic.NewIssue()
+			node.SetIndex(issue.Index)
+			node.SetTitle(issue.Title)
+			node.SetContent(issue.Body)
+			node.SetPosterName(issue.Poster.UserName)
+			node.SetIsClosed(issue.State == gitea.StateClosed)
+			if err := fn(node, i < len(issues)-1 || len(issues) == perPage); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *f3generic.PullRequest:
+		prs, _, err := d.client.ListRepoPullRequests(d.owner, d.repo, gitea.ListPullRequestsOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: perPage},
+		})
+		if err != nil {
+			return err
+		}
+		for i, pr := range prs {
+			node := f3generic.NewPullRequest()
+			node.SetIndex(pr.Index)
+			node.SetTitle(pr.Title)
+			node.SetContent(pr.Body)
+			node.SetPosterName(pr.Poster.UserName)
+			node.SetIsClosed(pr.State == gitea.StateClosed)
+			if err := fn(node, i < len(prs)-1 || len(prs) == perPage); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("f3: GiteaDriver.ForEachPage does not support %T", template)
+	}
+}
+
+// ForEachChild lists nodes of template's type that belong to parent (e.g.
+// every Comment on a given Issue)
+func (d *GiteaDriver) ForEachChild(ctx context.Context, parent f3generic.ID, template f3generic.Node, fn func(f3generic.Node) error) error {
+	switch template.(type) {
+	case *f3generic.Comment:
+		index, err := parent.Int64()
+		if err != nil {
+			return err
+		}
+		comments, _, err := d.client.ListIssueComments(d.owner, d.repo, index, gitea.ListIssueCommentOptions{})
+		if err != nil {
+			return err
+		}
+		for _, comment := range comments {
+			node := f3generic.NewComment()
+			node.SetPosterName(comment.Poster.UserName)
+			node.SetContent(comment.Body)
+			node.SetCreated(comment.Created)
+			node.SetUpdated(comment.Updated)
+			if err := fn(node); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *f3generic.Review:
+		index, err := parent.Int64()
+		if err != nil {
+			return err
+		}
+		reviews, _, err := d.client.ListPullReviews(d.owner, d.repo, index, gitea.ListPullReviewsOptions{})
+		if err != nil {
+			return err
+		}
+		for _, review := range reviews {
+			node := f3generic.NewReview()
+			node.SetContent(review.Body)
+			if err := fn(node); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("f3: GiteaDriver.ForEachChild does not support %T", template)
+	}
+}
@@ -0,0 +1,12 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/services/migrations/f3"
+)
+
+func init() {
+	RegisterDownloaderFactory(&f3.F3DownloaderFactory{})
+}
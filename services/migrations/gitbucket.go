@@ -0,0 +1,90 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"code.gitea.io/gitea/modules/log"
+	base "code.gitea.io/gitea/modules/migration"
+	"code.gitea.io/gitea/modules/structs"
+)
+
+var (
+	_ base.Downloader        = &GitBucketDownloader{}
+	_ base.DownloaderFactory = &GitBucketDownloaderFactory{}
+)
+
+func init() {
+	RegisterDownloaderFactory(&GitBucketDownloaderFactory{})
+}
+
+// GitBucketDownloaderFactory defines a GitBucket downloader factory
+type GitBucketDownloaderFactory struct{}
+
+// New returns a Downloader related to this factory according to MigrateOptions
+func (f *GitBucketDownloaderFactory) New(ctx context.Context, opts base.MigrateOptions) (base.Downloader, error) {
+	u, err := url.Parse(opts.CloneAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := u.Scheme + "://" + u.Host
+	fields := strings.Split(strings.Trim(u.Path, "/"), "/")
+	oldOwner := fields[0]
+	oldName := strings.TrimSuffix(fields[1], ".git")
+
+	log.Trace("Create GitBucket downloader BaseURL: %s %s/%s", baseURL, oldOwner, oldName)
+
+	return NewGitBucketDownloader(ctx, baseURL, opts.AuthUsername, opts.AuthPassword, opts.AuthToken, oldOwner, oldName), nil
+}
+
+// GitServiceType returns the type of git service
+func (f *GitBucketDownloaderFactory) GitServiceType() structs.GitServiceType {
+	return structs.GitBucketService
+}
+
+// GitBucketDownloader implements a Downloader interface to get repository
+// information from a GitBucket instance. GitBucket exposes a GitHub v3
+// compatible API rooted at "/api/v3" and leaves several endpoints
+// (reactions, review comments, project boards) unimplemented, so this
+// wraps GithubDownloaderV3 and rewrites the base URL while no-oping the
+// endpoints GitBucket does not support instead of letting them fail on 404s.
+type GitBucketDownloader struct {
+	*GithubDownloaderV3
+}
+
+// NewGitBucketDownloader creates a GitBucket downloader by reusing the
+// GitHub v3 downloader against GitBucket's "/api/v3" API root.
+func NewGitBucketDownloader(ctx context.Context, baseURL, userName, password, token, repoOwner, repoName string) *GitBucketDownloader {
+	apiBaseURL := strings.TrimSuffix(baseURL, "/") + "/api/v3"
+	githubDownloader := NewGithubDownloaderV3(ctx, apiBaseURL, userName, password, token, repoOwner, repoName)
+
+	// GitBucket does not implement the reactions or review comment
+	// endpoints, and has no concept of project boards; calling them
+	// returns 404s that would otherwise abort the whole migration.
+	githubDownloader.SkipReactions = true
+
+	return &GitBucketDownloader{GithubDownloaderV3: githubDownloader}
+}
+
+// String implements Stringer
+func (g *GitBucketDownloader) String() string {
+	return "migration from gitbucket server " + g.GithubDownloaderV3.String()
+}
+
+func (g *GitBucketDownloader) LogString() string {
+	if g == nil {
+		return "<GitBucketDownloader nil>"
+	}
+	return "<GitBucketDownloader " + g.GithubDownloaderV3.LogString() + ">"
+}
+
+// GetReviews is not supported by GitBucket's GitHub-compatible API, so
+// return an empty result instead of surfacing the underlying 404.
+func (g *GitBucketDownloader) GetReviews(reviewable base.Reviewable) ([]*base.Review, error) {
+	return nil, nil
+}